@@ -0,0 +1,22 @@
+// Package all registers every implementation of the benchmark. Import it for
+// its side effects to enumerate them with benchflix.Implementations.
+package all
+
+import (
+	_ "github.com/wroge/bench-flix/bob-flix"
+	_ "github.com/wroge/bench-flix/bun-flix"
+	_ "github.com/wroge/bench-flix/dbr-flix"
+	_ "github.com/wroge/bench-flix/ent-flix"
+	_ "github.com/wroge/bench-flix/goqu-flix"
+	_ "github.com/wroge/bench-flix/gorm-flix"
+	_ "github.com/wroge/bench-flix/jet-flix"
+	_ "github.com/wroge/bench-flix/sql-flix"
+	_ "github.com/wroge/bench-flix/sqlboiler-flix"
+	_ "github.com/wroge/bench-flix/sqlc-flix"
+	_ "github.com/wroge/bench-flix/sqlt-flix"
+	_ "github.com/wroge/bench-flix/sqlx-flix"
+	_ "github.com/wroge/bench-flix/squirrel-flix"
+	_ "github.com/wroge/bench-flix/upperdb-flix"
+	_ "github.com/wroge/bench-flix/xorm-flix"
+	_ "github.com/wroge/bench-flix/zombiezen-flix"
+)
@@ -2,6 +2,9 @@ package benchflix
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
 	"strconv"
 	"strings"
 	"time"
@@ -24,16 +27,129 @@ type Query struct {
 	Country                 string
 	AddedBefore, AddedAfter time.Time
 	MinRating, MaxRating    float64
+	Sort                    Sort
 	Limit                   uint64
+	Offset                  uint64
+	After                   *Cursor
 }
 
+type SortField string
+
+const (
+	SortByTitle   SortField = "title"
+	SortByAddedAt SortField = "added_at"
+	SortByRating  SortField = "rating"
+	SortByID      SortField = "id"
+)
+
+// Sort orders the result of a Query by Field. Movies with equal values are
+// ordered by ID in the same direction. The zero value sorts by title ascending.
+type Sort struct {
+	Field      SortField
+	Descending bool
+}
+
+// Column returns the column of the movies table to sort by.
+func (s Sort) Column() (string, error) {
+	switch s.Field {
+	case "":
+		return string(SortByTitle), nil
+	case SortByTitle, SortByAddedAt, SortByRating, SortByID:
+		return string(s.Field), nil
+	default:
+		return "", fmt.Errorf("benchflix: invalid sort field %q", s.Field)
+	}
+}
+
+func (s Sort) Direction() string {
+	if s.Descending {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+// Cursor is the last seen row of the previous page. Queries with a cursor only
+// return movies that are ordered after it (keyset pagination).
+type Cursor struct {
+	Title   string
+	AddedAt time.Time
+	Rating  float64
+	ID      int64
+}
+
+func NewCursor(movie Movie) *Cursor {
+	return &Cursor{
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+		ID:      movie.ID,
+	}
+}
+
+// Value returns the value of the cursor the given sort field compares with.
+func (c Cursor) Value(field SortField) any {
+	switch field {
+	case SortByAddedAt:
+		return c.AddedAt
+	case SortByRating:
+		return c.Rating
+	case SortByID:
+		return c.ID
+	default:
+		return c.Title
+	}
+}
+
+var (
+	// ErrNotFound is returned by Read, Update and Delete if no movie has the
+	// given ID.
+	ErrNotFound = errors.New("benchflix: movie not found")
+	// ErrAlreadyExists is returned by Create if a movie with the same ID
+	// already exists.
+	ErrAlreadyExists = errors.New("benchflix: movie already exists")
+)
+
 type Repository interface {
 	Create(ctx context.Context, movie Movie) error
 	Read(ctx context.Context, id int64) (Movie, error)
 	Query(ctx context.Context, query Query) ([]Movie, error)
+	Update(ctx context.Context, movie Movie) error
 	Delete(ctx context.Context, id int64) error
 }
 
+// BulkCreator is implemented by repositories that can insert many movies
+// within a single transaction using batched statements.
+type BulkCreator interface {
+	CreateMany(ctx context.Context, movies []Movie) error
+}
+
+// Streamer is implemented by repositories that can yield the movies of a Query
+// while scanning them instead of collecting them into a slice. Iteration stops
+// after the first error.
+type Streamer interface {
+	Stream(ctx context.Context, query Query) iter.Seq2[Movie, error]
+}
+
+// Faceter is implemented by repositories that can aggregate the movies matching
+// the filters of a Query. Sort, Limit, Offset and After are ignored.
+type Faceter interface {
+	Facets(ctx context.Context, query Query) (Facets, error)
+}
+
+// Facets holds the total number of matching movies and how many of them belong
+// to each genre and country, ordered by count descending and name ascending.
+type Facets struct {
+	Count     int64
+	Genres    []FacetCount
+	Countries []FacetCount
+}
+
+type FacetCount struct {
+	Name  string
+	Count int64
+}
+
 func NewMovie(record []string) (Movie, error) {
 	id, err := strconv.ParseInt(record[0], 10, 64)
 	if err != nil {
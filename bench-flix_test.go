@@ -1,77 +1,94 @@
 package benchflix_test
 
 import (
+	"cmp"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"math"
+	"math/bits"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	benchflix "github.com/wroge/bench-flix"
-	bunflix "github.com/wroge/bench-flix/bun-flix"
-	entflix "github.com/wroge/bench-flix/ent-flix"
-	gormflix "github.com/wroge/bench-flix/gorm-flix"
-	sqlflix "github.com/wroge/bench-flix/sql-flix"
-	sqlcflix "github.com/wroge/bench-flix/sqlc-flix"
-	sqltflix "github.com/wroge/bench-flix/sqlt-flix"
-	sqlxflix "github.com/wroge/bench-flix/sqlx-flix"
+	_ "github.com/wroge/bench-flix/all"
+	flixdrivers "github.com/wroge/bench-flix/drivers"
 )
 
+var (
+	only    = flag.String("flix.only", "", "comma separated implementations to run, e.g. sql,gorm,sql@modernc (default all on mattn/go-sqlite3)")
+	drivers = flag.String("flix.drivers", "", "comma separated SQLite drivers whose name@driver variants run too, e.g. modernc")
+	trace   = flag.String("flix.trace", "", "directory to write the statements of every Test_Query and Test_Read case to, with their arguments, per implementation; benchmarks are not traced")
+	update  = flag.Bool("flix.update", false, "update the snapshots of the queries in testdata/golden")
+	plans   = flag.String("flix.plans", "", "file to write the query plans of the statements of every Query case to, e.g. plans.out")
+)
+
+// inits holds the registered implementations selected with -flix.only and
+// -flix.drivers. It is filled by TestMain, after the flags are parsed.
+var inits []Init
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	var names []string
+
+	for _, name := range benchflix.Implementations() {
+		_, driver, variant := strings.Cut(name, "@")
+		if !variant || slices.Contains(strings.Split(*drivers, ","), driver) {
+			names = append(names, name)
+		}
+	}
+
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+
+	for _, name := range names {
+		if !slices.Contains(benchflix.Implementations(), name) {
+			fmt.Fprintf(os.Stderr, "-flix.only: unknown implementation %q, registered are %v\n", name, benchflix.Implementations())
+			os.Exit(2)
+		}
+
+		inits = append(inits, Init{Name: name})
+	}
+
+	os.Exit(m.Run())
+}
+
 type Init struct {
 	Name string
-	New  func() benchflix.Repository
 }
 
-var inits = []Init{
-	{
-		"sql",
-		func() benchflix.Repository {
-			return sqlflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	{
-		"gorm",
-		func() benchflix.Repository {
-			return gormflix.NewRepository(":memory:?_fk=1")
-		},
-	},
-	{
-		"sqlt",
-		func() benchflix.Repository {
-			return sqltflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	{
-		"ent",
-		func() benchflix.Repository {
-			return entflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	{
-		"sqlc",
-		func() benchflix.Repository {
-			return sqlcflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	{
-		"bun",
-		func() benchflix.Repository {
-			return bunflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	{
-		"sqlx",
-		func() benchflix.Repository {
-			return sqlxflix.NewRepository("sqlite3", ":memory:?_fk=1")
-		},
-	},
-	// {
-	// 	"bob",
-	// 	func() benchflix.Repository {
-	// 		return bobflix.NewRepository("sqlite3", ":memory:?_fk=1")
-	// 	},
-	// },
+// New returns an empty repository backed by an in-memory database.
+func (i Init) New(tb testing.TB) benchflix.Repository {
+	return i.open(tb, ":memory:?_fk=1")
+}
+
+// NewFile returns an empty repository backed by a database file in WAL mode,
+// so that concurrent connections really read and write in parallel.
+func (i Init) NewFile(tb testing.TB) benchflix.Repository {
+	return i.open(tb, "file:"+filepath.Join(tb.TempDir(), i.Name+".db")+
+		"?_fk=1&_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=10000&_txlock=immediate")
+}
+
+func (i Init) open(tb testing.TB, dsn string) benchflix.Repository {
+	r, err := benchflix.Open(i.Name, dsn)
+	if err != nil {
+		tb.Fatal(i.Name, err)
+	}
+
+	return r
 }
 
 type Case struct {
@@ -86,6 +103,18 @@ type IDCase struct {
 	Result string
 }
 
+type UpdateCase struct {
+	Movie  benchflix.Movie
+	Result string
+}
+
+type FacetCase struct {
+	Name   string
+	Query  benchflix.Query
+	Count  int64
+	Genres string
+}
+
 var (
 	queryCases = []Case{
 		{
@@ -136,6 +165,32 @@ var (
 			},
 			ResultLen: 1000,
 		},
+		{
+			Name: "RatingDesc",
+			Query: benchflix.Query{
+				MinRating: 5,
+				Sort:      benchflix.Sort{Field: benchflix.SortByRating, Descending: true},
+				Limit:     100,
+			},
+			ResultLen: 100,
+		},
+		{
+			Name: "AddedAt",
+			Query: benchflix.Query{
+				MinRating: 5,
+				Sort:      benchflix.Sort{Field: benchflix.SortByAddedAt},
+				Limit:     100,
+			},
+			ResultLen: 100,
+		},
+		{
+			Name: "IDDesc",
+			Query: benchflix.Query{
+				Sort:  benchflix.Sort{Field: benchflix.SortByID, Descending: true},
+				Limit: 100,
+			},
+			ResultLen: 100,
+		},
 	}
 
 	idCases = []IDCase{
@@ -144,6 +199,65 @@ var (
 			Result: `{10192 Shrek Forever After 2010-05-16 00:00:00 +0000 UTC [Mike Mitchell] [Antonio Banderas Cameron Diaz Eddie Murphy Mike Myers Walt Dohrn] [United States of America] 6.38 [Adventure Animation Comedy Family Fantasy]}`,
 		},
 	}
+
+	// commaCase guards against splitting aggregated names on commas.
+	commaCase = UpdateCase{
+		Movie: benchflix.Movie{
+			ID:        1,
+			Title:     "Ocean's Eleven",
+			AddedAt:   time.Date(1960, 8, 10, 0, 0, 0, 0, time.UTC),
+			Directors: []string{"Lewis Milestone"},
+			Actors:    []string{"Sammy Davis, Jr.", "Frank Sinatra", "Dean Martin"},
+			Countries: []string{"United States of America", "Korea, Republic of"},
+			Rating:    6.5,
+			Genres:    []string{"Comedy", "Crime"},
+		},
+		Result: `{1 Ocean's Eleven 1960-08-10 00:00:00 +0000 UTC [Lewis Milestone] [Dean Martin Frank Sinatra Sammy Davis, Jr.] [Korea, Republic of United States of America] 6.5 [Comedy Crime]}`,
+	}
+
+	updateCases = []UpdateCase{
+		{
+			Movie: benchflix.Movie{
+				ID:        10192,
+				Title:     "Shrek Forever After (Extended)",
+				AddedAt:   time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC),
+				Directors: []string{"Mike Mitchell", "Walt Dohrn"},
+				Actors:    []string{"Antonio Banderas", "Eddie Murphy", "Jane Doe"},
+				Countries: []string{"United Kingdom"},
+				Rating:    7.5,
+			},
+			Result: `{10192 Shrek Forever After (Extended) 2011-01-01 00:00:00 +0000 UTC [Mike Mitchell Walt Dohrn] [Antonio Banderas Eddie Murphy Jane Doe] [United Kingdom] 7.5 []}`,
+		},
+	}
+
+	facetCases = []FacetCase{
+		{
+			Name:   "All",
+			Query:  benchflix.Query{},
+			Count:  6003,
+			Genres: `[{Drama 1606} {Horror 1604} {Romance 1567} {Comedy 1561} {Thriller 1545} {Documentary 1517} {Action 1491} {Adventure 1} {Animation 1} {Family 1} {Fantasy 1}]`,
+		},
+		{
+			Name:   "Genre",
+			Query:  benchflix.Query{Genre: "Drama"},
+			Count:  1606,
+			Genres: `[{Drama 1606} {Romance 300} {Horror 295} {Thriller 291} {Comedy 290} {Documentary 281} {Action 278}]`,
+		},
+		{
+			Name:  "Rating",
+			Query: benchflix.Query{MinRating: 5, MaxRating: 8},
+			Count: 2165,
+		},
+		{
+			Name: "Complex",
+			Query: benchflix.Query{
+				Country:    "United Kingdom",
+				AddedAfter: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Count:  280,
+			Genres: `[{Documentary 80} {Thriller 77} {Drama 75} {Romance 70} {Action 67} {Comedy 65} {Horror 59}]`,
+		},
+	}
 )
 
 func BenchmarkSchemaAndCreate(b *testing.B) {
@@ -160,8 +274,10 @@ func BenchmarkSchemaAndCreate(b *testing.B) {
 	for _, init := range inits {
 		for _, num := range []int{10, 100, 1000} {
 			b.Run(fmt.Sprintf("%d_%s", num, init.Name), func(b *testing.B) {
+				s := countStatements()
+
 				for b.Loop() {
-					r := init.New()
+					r := init.New(b)
 
 					for _, record := range records[1:1000] {
 						movie, err := benchflix.NewMovie(record)
@@ -174,6 +290,8 @@ func BenchmarkSchemaAndCreate(b *testing.B) {
 						}
 					}
 				}
+
+				s.report(b)
 			})
 		}
 	}
@@ -190,7 +308,7 @@ func BenchmarkCreateAndDelete(b *testing.B) {
 		b.Fatal(err)
 	}
 
-	do := func(r benchflix.Repository, num int) {
+	do := func(r benchflix.Repository, num int, l *latency) {
 		ids := []int64{}
 
 		for _, record := range records[1:num] {
@@ -199,10 +317,14 @@ func BenchmarkCreateAndDelete(b *testing.B) {
 				b.Fatal(reflect.TypeOf(r), err)
 			}
 
+			start := time.Now()
+
 			if err = r.Create(b.Context(), movie); err != nil {
 				b.Fatal(reflect.TypeOf(r), err)
 			}
 
+			l.record(start)
+
 			ids = append(ids, movie.ID)
 		}
 
@@ -216,14 +338,21 @@ func BenchmarkCreateAndDelete(b *testing.B) {
 	for _, init := range inits {
 		for _, num := range []int{10, 100, 1000} {
 			b.Run(fmt.Sprintf("%d_%s", num, init.Name), func(b *testing.B) {
-				r := init.New()
+				r := init.New(b)
 
 				// Warmup
-				do(r, num)
+				do(r, num, &latency{})
+
+				var l latency
+
+				s := countStatements()
 
 				for b.Loop() {
-					do(r, num)
+					do(r, num, &l)
 				}
+
+				l.report(b)
+				s.report(b)
 			})
 		}
 	}
@@ -240,23 +369,47 @@ func Test_Query(t *testing.T) {
 		panic(err)
 	}
 
-	for _, c := range queryCases {
-		for _, init := range inits {
-			r := init.New()
+	// Every implementation must return exactly the same movies in the same
+	// order as the first one.
+	references := make([]string, len(queryCases))
+
+	var report queryPlans
+
+	// EXPLAIN QUERY PLAN doubles the statements, so the plans are only
+	// collected for -flix.plans.
+	start := flixdrivers.Trace
+	if *plans != "" {
+		start = flixdrivers.Explain
+	}
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if err = r.Create(t.Context(), movie); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+		}
 
+		for i, c := range queryCases {
 			t.Run(c.Name+"_"+init.Name, func(t *testing.T) {
-				for _, record := range records[1:] {
-					movie, err := benchflix.NewMovie(record)
-					if err != nil {
-						t.Fatal(reflect.TypeOf(r), err)
-					}
+				stop := start()
 
-					if err = r.Create(t.Context(), movie); err != nil {
-						t.Fatal(reflect.TypeOf(r), err)
-					}
+				movies, err := r.Query(t.Context(), c.Query)
+
+				statements := stop()
+
+				golden(t, init.Name, "Query_"+c.Name, statements)
+
+				if *plans != "" {
+					report.add(t, c.Name+"_"+init.Name, statements)
 				}
 
-				movies, err := r.Query(t.Context(), c.Query)
 				if err != nil {
 					t.Fatal(reflect.TypeOf(r), err)
 				}
@@ -269,66 +422,76 @@ func Test_Query(t *testing.T) {
 				if c.Result != "" && fmt.Sprint(movies) != c.Result {
 					t.Fatal(reflect.TypeOf(r), c.Query, movies)
 				}
+
+				if references[i] == "" {
+					references[i] = fmt.Sprint(movies)
+				} else if fmt.Sprint(movies) != references[i] {
+					t.Fatalf("%s: %v: result differs from %s", reflect.TypeOf(r), c.Query, inits[0].Name)
+				}
 			})
 		}
 	}
+
+	report.write(t)
 }
 
-func BenchmarkQuery(b *testing.B) {
-	file, err := os.Open("./movies.csv")
-	if err != nil {
-		b.Fatal(err)
-	}
+// joinTable matches a join table in a query and the alias that SQLite shows in
+// the query plan instead of its name, if any.
+var joinTable = regexp.MustCompile("(?i)[`\"]?(movie_directors|movie_actors|movie_countries|movie_genres)[`\"]?(?:\\s+AS)?\\s+[`\"]?(\\w+)")
 
-	records, err := csv.NewReader(file).ReadAll()
-	if err != nil {
-		b.Fatal(err)
-	}
+// queryPlans collects the query plans of the Query cases for -flix.plans and
+// flags every SCAN of a join table, which reads all links of all movies.
+type queryPlans struct {
+	scans strings.Builder
+	plans strings.Builder
+}
 
-	do := func(r benchflix.Repository, c Case) {
-		movies, err := r.Query(b.Context(), c.Query)
-		if err != nil {
-			b.Fatal(reflect.TypeOf(r), err)
-		}
+func (p *queryPlans) add(t *testing.T, name string, statements []flixdrivers.Statement) {
+	t.Helper()
 
-		if c.ResultLen != len(movies) {
-			b.Fatalf("%s: %v: invalid number of movies: want %d got %d",
-				reflect.TypeOf(r), c.Query, c.ResultLen, len(movies))
+	fmt.Fprintf(&p.plans, "=== %s\n", name)
+
+	for _, statement := range statements {
+		fmt.Fprintf(&p.plans, "%s\nQUERY PLAN\n", statement.Query)
+
+		tables := map[string]bool{
+			"movie_directors": true,
+			"movie_actors":    true,
+			"movie_countries": true,
+			"movie_genres":    true,
 		}
 
-		if c.Result != "" && fmt.Sprint(movies) != c.Result {
-			b.Fatal(reflect.TypeOf(r), c.Query, movies)
+		for _, match := range joinTable.FindAllStringSubmatch(statement.Query, -1) {
+			tables[match[2]] = true
 		}
-	}
 
-	for _, c := range queryCases {
-		for _, init := range inits {
-			r := init.New()
+		for _, line := range statement.Plan {
+			fmt.Fprintf(&p.plans, "%s\n", line)
 
-			for _, record := range records[1:] {
-				movie, err := benchflix.NewMovie(record)
-				if err != nil {
-					b.Fatal(reflect.TypeOf(r), err)
-				}
+			if fields := strings.Fields(line); len(fields) > 1 && fields[0] == "SCAN" && tables[fields[1]] {
+				t.Logf("%s: %s", name, strings.TrimSpace(line))
 
-				if err = r.Create(b.Context(), movie); err != nil {
-					b.Fatal(reflect.TypeOf(r), err)
-				}
+				fmt.Fprintf(&p.scans, "%s: %s\n", name, strings.TrimSpace(line))
 			}
+		}
 
-			// Warmup
-			do(r, c)
+		p.plans.WriteString("\n")
+	}
+}
 
-			b.Run(c.Name+"_"+init.Name, func(b *testing.B) {
-				for b.Loop() {
-					do(r, c)
-				}
-			})
-		}
+func (p *queryPlans) write(t *testing.T) {
+	if *plans == "" {
+		return
+	}
+
+	content := "SCAN over join tables:\n" + cmp.Or(p.scans.String(), "none\n") + "\n" + p.plans.String()
+
+	if err := os.WriteFile(*plans, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
 	}
 }
 
-func Test_Read(t *testing.T) {
+func Test_Differential(t *testing.T) {
 	file, err := os.Open("./movies.csv")
 	if err != nil {
 		t.Fatal(err)
@@ -339,80 +502,1685 @@ func Test_Read(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for _, c := range idCases {
-		for _, init := range inits {
-			r := init.New()
+	movies := make([]benchflix.Movie, len(records)-1)
 
-			t.Run(init.Name, func(t *testing.T) {
-				for _, record := range records[1:] {
-					movie, err := benchflix.NewMovie(record)
-					if err != nil {
-						t.Fatal(reflect.TypeOf(r), err)
-					}
+	for i, record := range records[1:] {
+		movies[i], err = benchflix.NewMovie(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
 
-					if err = r.Create(t.Context(), movie); err != nil {
-						t.Fatal(reflect.TypeOf(r), err)
-					}
+	reference := benchflix.NewMemoryRepository()
+
+	for _, movie := range movies {
+		if err = reference.Create(t.Context(), movie); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queries := randomQueries(rand.New(rand.NewPCG(1, 2)), movies, 200)
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			for _, movie := range movies {
+				if err := r.Create(t.Context(), movie); err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
 				}
+			}
 
-				movie, err := r.Read(t.Context(), c.ID)
+			for _, query := range queries {
+				want, err := reference.Query(t.Context(), query)
 				if err != nil {
-					t.Fatal(reflect.TypeOf(r), err)
+					t.Fatal(err)
 				}
 
-				if fmt.Sprint(movie) != c.Result {
-					t.Fatal(reflect.TypeOf(r), movie)
+				got, err := r.Query(t.Context(), query)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), query, err)
 				}
-			})
-		}
+
+				if d := diff(want, got); d != "" {
+					t.Fatalf("%s: %+v: %s", reflect.TypeOf(r), query, d)
+				}
+			}
+		})
 	}
 }
 
-func BenchmarkRead(b *testing.B) {
-	file, err := os.Open("./movies.csv")
-	if err != nil {
-		b.Fatal(err)
+// randomQueries generates queries from the real names, dates and ratings of
+// movies, so that most of them match something and hit the boundaries.
+func randomQueries(rnd *rand.Rand, movies []benchflix.Movie, n int) []benchflix.Query {
+	pick := func(list []string) string {
+		if len(list) == 0 {
+			return ""
+		}
+
+		return list[rnd.IntN(len(list))]
 	}
 
-	records, err := csv.NewReader(file).ReadAll()
-	if err != nil {
-		b.Fatal(err)
+	movie := func() benchflix.Movie {
+		return movies[rnd.IntN(len(movies))]
 	}
 
-	do := func(r benchflix.Repository, c IDCase) {
-		movie, err := r.Read(b.Context(), c.ID)
-		if err != nil {
-			b.Fatal(reflect.TypeOf(r), err)
+	sortFields := []benchflix.SortField{"", benchflix.SortByTitle, benchflix.SortByAddedAt, benchflix.SortByRating, benchflix.SortByID}
+
+	queries := make([]benchflix.Query, n)
+
+	for i := range queries {
+		q := &queries[i]
+
+		if rnd.IntN(3) == 0 {
+			name := []rune(pick(append(movie().Directors, movie().Actors...)))
+			if len(name) > 0 {
+				start := rnd.IntN(len(name))
+				q.Search = string(name[start:min(len(name), start+1+rnd.IntN(8))])
+			}
 		}
 
-		if fmt.Sprint(movie) != c.Result {
-			b.Fatal(reflect.TypeOf(r), movie)
+		if rnd.IntN(3) == 0 {
+			q.Genre = pick(movie().Genres)
 		}
-	}
 
-	for _, c := range idCases {
-		for _, init := range inits {
-			r := init.New()
+		if rnd.IntN(3) == 0 {
+			q.Country = pick(movie().Countries)
+		}
 
-			for _, record := range records[1:] {
-				movie, err := benchflix.NewMovie(record)
-				if err != nil {
-					b.Fatal(err)
-				}
+		if rnd.IntN(3) == 0 {
+			q.AddedAfter = movie().AddedAt
+		}
 
-				if err = r.Create(b.Context(), movie); err != nil {
-					b.Fatal(err)
-				}
-			}
+		if rnd.IntN(3) == 0 {
+			q.AddedBefore = movie().AddedAt
+		}
 
-			// Warmup
-			do(r, c)
+		if rnd.IntN(3) == 0 {
+			q.MinRating = movie().Rating
+		}
 
-			b.Run(init.Name, func(b *testing.B) {
-				for b.Loop() {
-					do(r, c)
-				}
-			})
+		if rnd.IntN(3) == 0 {
+			q.MaxRating = movie().Rating
+		}
+
+		q.Sort = benchflix.Sort{
+			Field:      sortFields[rnd.IntN(len(sortFields))],
+			Descending: rnd.IntN(2) == 0,
+		}
+
+		if rnd.IntN(5) > 0 {
+			q.Limit = uint64(1 + rnd.IntN(50))
+		}
+
+		if rnd.IntN(3) == 0 {
+			q.Offset = uint64(rnd.IntN(100))
+		}
+
+		if rnd.IntN(5) == 0 {
+			q.After = benchflix.NewCursor(movie())
 		}
 	}
+
+	return queries
+}
+
+// latency records the duration of single operations, because ns/op is an
+// average that hides the slow ones. The durations are counted in a histogram with a
+// fixed number of buckets, 32 per power of two, so that recording does not
+// allocate within the timed loop and the percentiles are off by at most 3%.
+type latency struct {
+	counts [latencyBuckets]int64
+	total  int64
+	max    time.Duration
+}
+
+const (
+	latencyBits    = 5
+	latencyBuckets = (64 - latencyBits) << latencyBits
+)
+
+func (l *latency) record(start time.Time) {
+	duration := time.Since(start)
+
+	l.counts[latencyBucket(duration)]++
+	l.total++
+	l.max = max(l.max, duration)
+}
+
+// latencyBucket returns the bucket of duration. Durations below 64ns have a
+// bucket of their own, longer ones share it with durations of the same 6
+// leading bits.
+func latencyBucket(duration time.Duration) int {
+	n := uint64(max(duration, 0))
+
+	if n < 1<<(latencyBits+1) {
+		return int(n)
+	}
+
+	shift := bits.Len64(n) - (latencyBits + 1)
+
+	return (shift+1)<<latencyBits + int(n>>shift) - 1<<latencyBits
+}
+
+// latencyUpper returns the longest duration of a bucket.
+func latencyUpper(bucket int) time.Duration {
+	if bucket < 1<<(latencyBits+1) {
+		return time.Duration(bucket)
+	}
+
+	shift := bucket>>latencyBits - 1
+	leading := uint64(bucket&(1<<latencyBits-1) + 1<<latencyBits)
+
+	return time.Duration((leading+1)<<shift - 1)
+}
+
+// report adds the nearest-rank percentiles p50, p95, p99 and the maximum as
+// metrics in nanoseconds. A percentile is the longest duration of its bucket,
+// but never more than the maximum.
+func (l *latency) report(b *testing.B) {
+	if l.total == 0 {
+		return
+	}
+
+	for _, p := range []struct {
+		unit     string
+		quantile float64
+	}{
+		{"p50-ns", 0.50},
+		{"p95-ns", 0.95},
+		{"p99-ns", 0.99},
+	} {
+		rank := max(int64(math.Ceil(p.quantile*float64(l.total))), 1)
+
+		var seen int64
+
+		for bucket, count := range l.counts {
+			if seen += count; seen >= rank {
+				b.ReportMetric(float64(min(latencyUpper(bucket), l.max)), p.unit)
+
+				break
+			}
+		}
+	}
+
+	b.ReportMetric(float64(l.max), "max-ns")
+}
+
+// statements counts the statements and rows of the counting drivers, because
+// a library can hide an N+1 query behind a single call.
+type statements struct {
+	start flixdrivers.Count
+}
+
+func countStatements() statements {
+	return statements{start: flixdrivers.Counted()}
+}
+
+// report adds the statements and rows per operation as metrics.
+func (s statements) report(b *testing.B) {
+	count := flixdrivers.Counted().Sub(s.start)
+
+	b.ReportMetric(float64(count.Queries)/float64(b.N), "queries/op")
+	b.ReportMetric(float64(count.Rows)/float64(b.N), "rows/op")
+}
+
+// diff describes the first difference between two results.
+func diff(want, got []benchflix.Movie) string {
+	for i := range min(len(want), len(got)) {
+		if fmt.Sprint(want[i]) != fmt.Sprint(got[i]) {
+			return fmt.Sprintf("movie %d: want %v got %v", i, want[i], got[i])
+		}
+	}
+
+	switch {
+	case len(want) > len(got):
+		return fmt.Sprintf("want %d movies got %d: missing %v", len(want), len(got), want[len(got)])
+	case len(want) < len(got):
+		return fmt.Sprintf("want %d movies got %d: unexpected %v", len(want), len(got), got[len(want)])
+	default:
+		return ""
+	}
+}
+
+// FuzzQuery compares the filters of every implementation with the reference on
+// a small fixture. Dates are days since the Unix epoch and zero means unset.
+func FuzzQuery(f *testing.F) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	reference := benchflix.NewMemoryRepository()
+
+	repositories := make([]benchflix.Repository, len(inits))
+
+	for i, init := range inits {
+		repositories[i] = init.New(f)
+	}
+
+	for _, record := range records[1:301] {
+		movie, err := benchflix.NewMovie(record)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		if err = reference.Create(f.Context(), movie); err != nil {
+			f.Fatal(err)
+		}
+
+		for _, r := range repositories {
+			if err = r.Create(f.Context(), movie); err != nil {
+				f.Fatal(reflect.TypeOf(r), err)
+			}
+		}
+	}
+
+	f.Add("Affleck", "Drama", "United Kingdom", uint16(18262), uint16(20089), 4.0, 8.0, uint8(10))
+	f.Add("", "", "", uint16(0), uint16(0), 0.0, 0.0, uint8(0))
+
+	f.Fuzz(func(t *testing.T, search, genre, country string, addedAfter, addedBefore uint16, minRating, maxRating float64, limit uint8) {
+		// SQLite stores text as UTF-8.
+		if !utf8.ValidString(search+genre+country) || math.IsNaN(minRating) || math.IsNaN(maxRating) {
+			t.Skip()
+		}
+
+		query := benchflix.Query{
+			Search:    search,
+			Genre:     genre,
+			Country:   country,
+			MinRating: minRating,
+			MaxRating: maxRating,
+			Limit:     uint64(limit),
+		}
+
+		if addedAfter > 0 {
+			query.AddedAfter = time.Unix(int64(addedAfter)*24*60*60, 0).UTC()
+		}
+
+		if addedBefore > 0 {
+			query.AddedBefore = time.Unix(int64(addedBefore)*24*60*60, 0).UTC()
+		}
+
+		want, err := reference.Query(t.Context(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, r := range repositories {
+			got, err := r.Query(t.Context(), query)
+			if err != nil {
+				t.Fatalf("%s: %+v: %v", inits[i].Name, query, err)
+			}
+
+			if d := diff(want, got); d != "" {
+				t.Fatalf("%s: %+v: %s", inits[i].Name, query, d)
+			}
+		}
+	})
+}
+
+func BenchmarkQuery(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	do := func(r benchflix.Repository, c Case) {
+		movies, err := r.Query(b.Context(), c.Query)
+		if err != nil {
+			b.Fatal(reflect.TypeOf(r), err)
+		}
+
+		if c.ResultLen != len(movies) {
+			b.Fatalf("%s: %v: invalid number of movies: want %d got %d",
+				reflect.TypeOf(r), c.Query, c.ResultLen, len(movies))
+		}
+
+		if c.Result != "" && fmt.Sprint(movies) != c.Result {
+			b.Fatal(reflect.TypeOf(r), c.Query, movies)
+		}
+	}
+
+	for _, c := range queryCases {
+		for _, init := range inits {
+			r := init.New(b)
+
+			for _, record := range records[1:] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					b.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if err = r.Create(b.Context(), movie); err != nil {
+					b.Fatal(reflect.TypeOf(r), err)
+				}
+			}
+
+			// Warmup
+			do(r, c)
+
+			b.Run(c.Name+"_"+init.Name, func(b *testing.B) {
+				var l latency
+
+				s := countStatements()
+
+				for b.Loop() {
+					start := time.Now()
+
+					do(r, c)
+
+					l.record(start)
+				}
+
+				l.report(b)
+				s.report(b)
+			})
+		}
+	}
+}
+
+func Test_Read(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range idCases {
+		for _, init := range inits {
+			r := init.New(t)
+
+			t.Run(init.Name, func(t *testing.T) {
+				for _, record := range records[1:] {
+					movie, err := benchflix.NewMovie(record)
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					if err = r.Create(t.Context(), movie); err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+				}
+
+				stop := flixdrivers.Trace()
+
+				movie, err := r.Read(t.Context(), c.ID)
+
+				golden(t, init.Name, fmt.Sprintf("Read_%d", c.ID), stop())
+
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if fmt.Sprint(movie) != c.Result {
+					t.Fatal(reflect.TypeOf(r), movie)
+				}
+			})
+		}
+	}
+}
+
+// golden compares the queries of a case with the snapshot in
+// testdata/golden/<implementation>/<name>.sql, so that a library upgrade that
+// changes the generated SQL shows up as a diff, and writes the statements with
+// their arguments to the -flix.trace directory. The arguments are not part of
+// the snapshots, because sqlboiler collects the IDs to eager load in a map. The
+// driver variants share the snapshots of their implementation.
+func golden(t *testing.T, implementation, name string, statements []flixdrivers.Statement) {
+	t.Helper()
+
+	// Every implementation runs through a counting driver or reports its
+	// statements with Executed, so an empty trace means a statement was missed.
+	if len(statements) == 0 {
+		t.Fatalf("%s: no statements traced", implementation)
+	}
+
+	var queries, traced strings.Builder
+
+	for _, statement := range statements {
+		queries.WriteString(statement.Query)
+		queries.WriteString("\n\n")
+
+		traced.WriteString(statement.String())
+		traced.WriteString("\n\n")
+	}
+
+	if *trace != "" {
+		write(t, filepath.Join(*trace, implementation, name+".sql"), traced.String())
+	}
+
+	base, _, _ := strings.Cut(implementation, "@")
+	path := filepath.Join("testdata", "golden", base, name+".sql")
+
+	if *update {
+		write(t, path, queries.String())
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s: %v, create it with -flix.update", implementation, err)
+	}
+
+	if string(want) != queries.String() {
+		t.Errorf("%s: queries differ from %s, update it with -flix.update and review the diff:\n%s",
+			implementation, path, queries.String())
+	}
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_Statements catches N+1 queries: Read may use one statement per table of
+// the schema, and Query must use the same number of statements for any Limit,
+// including none.
+func Test_Statements(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const tables = 9
+
+	for _, init := range inits {
+		t.Run(init.Name, func(t *testing.T) {
+			r := init.New(t)
+
+			for _, record := range records[1:1001] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if err = r.Create(t.Context(), movie); err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+			}
+
+			start := flixdrivers.Counted()
+
+			if _, err := r.Read(t.Context(), idCases[0].ID); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			read := flixdrivers.Counted().Sub(start).Queries
+			if read == 0 {
+				t.Fatalf("%s: Read: no statements counted", reflect.TypeOf(r))
+			}
+
+			if read > tables {
+				t.Errorf("%s: Read: %d statements", reflect.TypeOf(r), read)
+			}
+
+			var first int64
+
+			// Limit 0 returns all 1000 movies.
+			for _, limit := range []uint64{1, 10, 100, 1000, 0} {
+				start := flixdrivers.Counted()
+
+				movies, err := r.Query(t.Context(), benchflix.Query{Limit: limit})
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if want := cmp.Or(int(limit), 1000); len(movies) != want {
+					t.Fatalf("%s: Limit %d: %d movies", reflect.TypeOf(r), limit, len(movies))
+				}
+
+				count := flixdrivers.Counted().Sub(start).Queries
+
+				if first == 0 {
+					first = count
+				} else if count != first {
+					t.Errorf("%s: Limit %d: %d statements, Limit 1: %d statements", reflect.TypeOf(r), limit, count, first)
+				}
+			}
+		})
+	}
+}
+
+// Test_Prepared checks that the prepared variants reuse every statement of a
+// Create, including the INSERTs whose shape depends on the number of names,
+// for the next Create with the same numbers of names.
+func Test_Prepared(t *testing.T) {
+	movie := func(id int64) benchflix.Movie {
+		name := func(kind string, i int) string {
+			return fmt.Sprintf("%s %d-%d", kind, id, i)
+		}
+
+		return benchflix.Movie{
+			ID:        id,
+			Title:     fmt.Sprint("Movie ", id),
+			AddedAt:   time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			Directors: []string{name("Director", 1)},
+			Actors:    []string{name("Actor", 1), name("Actor", 2), name("Actor", 3)},
+			Countries: []string{name("Country", 1), name("Country", 2)},
+			Rating:    7,
+			Genres:    []string{name("Genre", 1), name("Genre", 2)},
+		}
+	}
+
+	for _, init := range inits {
+		if base, _, _ := strings.Cut(init.Name, "@"); !strings.HasSuffix(base, "-prepared") {
+			continue
+		}
+
+		t.Run(init.Name, func(t *testing.T) {
+			r := init.New(t)
+
+			if err := r.Create(t.Context(), movie(1)); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			start := flixdrivers.Counted()
+
+			if err := r.Create(t.Context(), movie(2)); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if prepares := flixdrivers.Counted().Sub(start).Prepares; prepares != 0 {
+				t.Errorf("%s: second Create: %d prepares", reflect.TypeOf(r), prepares)
+			}
+		})
+	}
+}
+
+func Test_CommaNames(t *testing.T) {
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			if err := r.Create(t.Context(), commaCase.Movie); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			movie, err := r.Read(t.Context(), commaCase.Movie.ID)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if fmt.Sprint(movie) != commaCase.Result {
+				t.Fatal(reflect.TypeOf(r), movie)
+			}
+
+			query := benchflix.Query{Search: "Davis, Jr.", Country: "Korea, Republic of"}
+
+			movies, err := r.Query(t.Context(), query)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if fmt.Sprint(movies) != "["+commaCase.Result+"]" {
+				t.Fatal(reflect.TypeOf(r), movies)
+			}
+
+			if streamer, ok := r.(benchflix.Streamer); ok {
+				for movie, err := range streamer.Stream(t.Context(), query) {
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					if fmt.Sprint(movie) != commaCase.Result {
+						t.Fatal(reflect.TypeOf(r), movie)
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeNames compares splitting names aggregated with GROUP_CONCAT
+// to decoding them from json_group_array.
+func BenchmarkDecodeNames(b *testing.B) {
+	names := []string{"Anne Hathaway", "Ben Affleck", "Edi Gathegi", "Rosie Perez", "Willem Dafoe"}
+
+	concat := strings.Join(names, ",")
+
+	array, err := json.Marshal(names)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Split", func(b *testing.B) {
+		for b.Loop() {
+			if len(strings.Split(concat, ",")) != len(names) {
+				b.Fatal(concat)
+			}
+		}
+	})
+
+	b.Run("JSON", func(b *testing.B) {
+		for b.Loop() {
+			var decoded []string
+
+			if err := json.Unmarshal(array, &decoded); err != nil {
+				b.Fatal(err)
+			}
+
+			if len(decoded) != len(names) {
+				b.Fatal(string(array))
+			}
+		}
+	})
+}
+
+func BenchmarkRead(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	do := func(r benchflix.Repository, c IDCase) {
+		movie, err := r.Read(b.Context(), c.ID)
+		if err != nil {
+			b.Fatal(reflect.TypeOf(r), err)
+		}
+
+		if fmt.Sprint(movie) != c.Result {
+			b.Fatal(reflect.TypeOf(r), movie)
+		}
+	}
+
+	for _, c := range idCases {
+		for _, init := range inits {
+			r := init.New(b)
+
+			for _, record := range records[1:] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if err = r.Create(b.Context(), movie); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			// Warmup
+			do(r, c)
+
+			b.Run(init.Name, func(b *testing.B) {
+				var l latency
+
+				s := countStatements()
+
+				for b.Loop() {
+					start := time.Now()
+
+					do(r, c)
+
+					l.record(start)
+				}
+
+				l.report(b)
+				s.report(b)
+			})
+		}
+	}
+}
+
+// loadFile creates every movie of records in a file-backed repository.
+func loadFile(b *testing.B, init Init, records [][]string) benchflix.Repository {
+	r := init.NewFile(b)
+
+	for _, record := range records[1:] {
+		movie, err := benchflix.NewMovie(record)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if err = r.Create(b.Context(), movie); err != nil {
+			b.Fatal(reflect.TypeOf(r), err)
+		}
+	}
+
+	return r
+}
+
+func BenchmarkReadParallel(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, init := range inits {
+		r := loadFile(b, init, records)
+
+		for _, c := range idCases {
+			b.Run(init.Name, func(b *testing.B) {
+				s := countStatements()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						movie, err := r.Read(b.Context(), c.ID)
+						if err != nil {
+							b.Error(reflect.TypeOf(r), err)
+
+							return
+						}
+
+						if fmt.Sprint(movie) != c.Result {
+							b.Error(reflect.TypeOf(r), movie)
+
+							return
+						}
+					}
+				})
+
+				s.report(b)
+			})
+		}
+	}
+}
+
+func BenchmarkQueryParallel(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, init := range inits {
+		r := loadFile(b, init, records)
+
+		for _, c := range queryCases {
+			b.Run(c.Name+"_"+init.Name, func(b *testing.B) {
+				s := countStatements()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						movies, err := r.Query(b.Context(), c.Query)
+						if err != nil {
+							b.Error(reflect.TypeOf(r), err)
+
+							return
+						}
+
+						if c.ResultLen != len(movies) {
+							b.Errorf("%s: %v: invalid number of movies: want %d got %d",
+								reflect.TypeOf(r), c.Query, c.ResultLen, len(movies))
+
+							return
+						}
+					}
+				})
+
+				s.report(b)
+			})
+		}
+	}
+}
+
+// BenchmarkMixed runs queries while every fourth goroutine creates and deletes
+// movies, so readers compete with writers for connections and locks. Writers
+// copy fixture movies under new IDs to keep the result of the query stable.
+func BenchmarkMixed(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	template, err := benchflix.NewMovie(records[1])
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	c := queryCases[0]
+
+	for _, init := range inits {
+		r := loadFile(b, init, records)
+
+		b.Run(init.Name, func(b *testing.B) {
+			var goroutines, ids atomic.Int64
+
+			ids.Store(1 << 32)
+
+			s := countStatements()
+
+			b.RunParallel(func(pb *testing.PB) {
+				writer := goroutines.Add(1)%4 == 0
+
+				for pb.Next() {
+					if writer {
+						movie := template
+						movie.ID = ids.Add(1)
+
+						if err := r.Create(b.Context(), movie); err != nil {
+							b.Error(reflect.TypeOf(r), err)
+
+							return
+						}
+
+						if err := r.Delete(b.Context(), movie.ID); err != nil {
+							b.Error(reflect.TypeOf(r), err)
+
+							return
+						}
+
+						continue
+					}
+
+					movies, err := r.Query(b.Context(), c.Query)
+					if err != nil {
+						b.Error(reflect.TypeOf(r), err)
+
+						return
+					}
+
+					if fmt.Sprint(movies) != c.Result {
+						b.Error(reflect.TypeOf(r), c.Query, movies)
+
+						return
+					}
+				}
+			})
+
+			s.report(b)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range updateCases {
+		for _, init := range inits {
+			r := init.New(t)
+
+			t.Run(init.Name, func(t *testing.T) {
+				for _, record := range records[1:] {
+					movie, err := benchflix.NewMovie(record)
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					if err = r.Create(t.Context(), movie); err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+				}
+
+				if err := r.Update(t.Context(), c.Movie); err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				movie, err := r.Read(t.Context(), c.Movie.ID)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if fmt.Sprint(movie) != c.Result {
+					t.Fatal(reflect.TypeOf(r), movie)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, c := range updateCases {
+		for _, init := range inits {
+			r := init.New(b)
+
+			for _, record := range records[1:] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if err = r.Create(b.Context(), movie); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			original, err := r.Read(b.Context(), c.Movie.ID)
+			if err != nil {
+				b.Fatal(reflect.TypeOf(r), err)
+			}
+
+			// Alternate between the updated and the original movie, so that every
+			// iteration replaces the title, rating and all relations.
+			movies := []benchflix.Movie{c.Movie, original}
+
+			b.Run(init.Name, func(b *testing.B) {
+				index := 0
+
+				s := countStatements()
+
+				for b.Loop() {
+					if err := r.Update(b.Context(), movies[index%2]); err != nil {
+						b.Fatal(reflect.TypeOf(r), err)
+					}
+
+					index++
+				}
+
+				s.report(b)
+			})
+		}
+	}
+}
+
+func Test_Errors(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movies := make([]benchflix.Movie, 3)
+
+	for i, record := range records[1:4] {
+		movies[i], err = benchflix.NewMovie(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const missing = -1
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			if err := r.Create(t.Context(), movies[0]); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			want, err := r.Read(t.Context(), movies[0].ID)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if _, err := r.Read(t.Context(), missing); !errors.Is(err, benchflix.ErrNotFound) {
+				t.Fatalf("%s: Read: want ErrNotFound got %v", reflect.TypeOf(r), err)
+			}
+
+			if err := r.Update(t.Context(), benchflix.Movie{ID: missing, Title: "Missing"}); !errors.Is(err, benchflix.ErrNotFound) {
+				t.Fatalf("%s: Update: want ErrNotFound got %v", reflect.TypeOf(r), err)
+			}
+
+			if err := r.Delete(t.Context(), missing); !errors.Is(err, benchflix.ErrNotFound) {
+				t.Fatalf("%s: Delete: want ErrNotFound got %v", reflect.TypeOf(r), err)
+			}
+
+			duplicate := movies[1]
+			duplicate.ID = movies[0].ID
+
+			if err := r.Create(t.Context(), duplicate); !errors.Is(err, benchflix.ErrAlreadyExists) {
+				t.Fatalf("%s: Create: want ErrAlreadyExists got %v", reflect.TypeOf(r), err)
+			}
+
+			// The failed Create must not have changed the existing movie.
+			got, err := r.Read(t.Context(), movies[0].ID)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("%s: want %v got %v", reflect.TypeOf(r), want, got)
+			}
+
+			if bulk, ok := r.(benchflix.BulkCreator); ok {
+				err := bulk.CreateMany(t.Context(), []benchflix.Movie{movies[2], movies[0]})
+				if !errors.Is(err, benchflix.ErrAlreadyExists) {
+					t.Fatalf("%s: CreateMany: want ErrAlreadyExists got %v", reflect.TypeOf(r), err)
+				}
+
+				if _, err := r.Read(t.Context(), movies[2].ID); !errors.Is(err, benchflix.ErrNotFound) {
+					t.Fatalf("%s: CreateMany: want rollback got %v", reflect.TypeOf(r), err)
+				}
+			}
+
+			if err := r.Delete(t.Context(), movies[0].ID); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if err := r.Delete(t.Context(), movies[0].ID); !errors.Is(err, benchflix.ErrNotFound) {
+				t.Fatalf("%s: Delete: want ErrNotFound got %v", reflect.TypeOf(r), err)
+			}
+
+			if _, err := r.Read(t.Context(), movies[0].ID); !errors.Is(err, benchflix.ErrNotFound) {
+				t.Fatalf("%s: Read: want ErrNotFound got %v", reflect.TypeOf(r), err)
+			}
+		})
+	}
+}
+
+func Test_CreateMany(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	movies := make([]benchflix.Movie, len(records)-1)
+
+	for i, record := range records[1:] {
+		movies[i], err = benchflix.NewMovie(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			bulk, ok := r.(benchflix.BulkCreator)
+			if !ok {
+				t.Skip(reflect.TypeOf(r), "does not implement BulkCreator")
+			}
+
+			if err := bulk.CreateMany(t.Context(), movies); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			for _, c := range idCases {
+				movie, err := r.Read(t.Context(), c.ID)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if fmt.Sprint(movie) != c.Result {
+					t.Fatal(reflect.TypeOf(r), movie)
+				}
+			}
+
+			for _, c := range queryCases {
+				result, err := r.Query(t.Context(), c.Query)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if c.ResultLen != len(result) {
+					t.Fatalf("%s: %v: invalid number of movies: want %d got %d",
+						reflect.TypeOf(r), c.Query, c.ResultLen, len(result))
+				}
+
+				if c.Result != "" && fmt.Sprint(result) != c.Result {
+					t.Fatal(reflect.TypeOf(r), c.Query, result)
+				}
+			}
+		})
+	}
+}
+
+func Test_Stream(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			streamer, ok := r.(benchflix.Streamer)
+			if !ok {
+				t.Skip(reflect.TypeOf(r), "does not implement Streamer")
+			}
+
+			for _, record := range records[1:] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if err = r.Create(t.Context(), movie); err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+			}
+
+			for _, c := range queryCases {
+				var movies []benchflix.Movie
+
+				for movie, err := range streamer.Stream(t.Context(), c.Query) {
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					movies = append(movies, movie)
+				}
+
+				if c.ResultLen != len(movies) {
+					t.Fatalf("%s: %v: invalid number of movies: want %d got %d",
+						reflect.TypeOf(r), c.Query, c.ResultLen, len(movies))
+				}
+
+				result, err := r.Query(t.Context(), c.Query)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if fmt.Sprint(movies) != fmt.Sprint(result) {
+					t.Fatalf("%s: %v: stream differs from query", reflect.TypeOf(r), c.Query)
+				}
+			}
+
+			// Stopping early must release the connection, otherwise the next
+			// statement opens a new and empty in-memory database.
+			for range 10 {
+				for _, err := range streamer.Stream(t.Context(), benchflix.Query{}) {
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					break
+				}
+			}
+
+			movies, err := r.Query(t.Context(), benchflix.Query{Limit: 1})
+			if err != nil || len(movies) != 1 {
+				t.Fatal(reflect.TypeOf(r), err, movies)
+			}
+		})
+	}
+}
+
+func BenchmarkStream(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	query := benchflix.Query{
+		MinRating: 5,
+		Limit:     1000,
+	}
+
+	for _, init := range inits {
+		r := init.New(b)
+
+		streamer, ok := r.(benchflix.Streamer)
+		if !ok {
+			continue
+		}
+
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if err = r.Create(b.Context(), movie); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.Run("Slice_"+init.Name, func(b *testing.B) {
+			s := countStatements()
+
+			for b.Loop() {
+				movies, err := r.Query(b.Context(), query)
+				if err != nil {
+					b.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if len(movies) != int(query.Limit) {
+					b.Fatal(reflect.TypeOf(r), len(movies))
+				}
+			}
+
+			s.report(b)
+		})
+
+		b.Run("Stream_"+init.Name, func(b *testing.B) {
+			s := countStatements()
+
+			for b.Loop() {
+				var count uint64
+
+				for _, err := range streamer.Stream(b.Context(), query) {
+					if err != nil {
+						b.Fatal(reflect.TypeOf(r), err)
+					}
+
+					count++
+				}
+
+				if count != query.Limit {
+					b.Fatal(reflect.TypeOf(r), count)
+				}
+			}
+
+			s.report(b)
+		})
+	}
+}
+
+func Test_Facets(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every implementation must return exactly the same facets as the first one.
+	references := map[string]string{}
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		t.Run(init.Name, func(t *testing.T) {
+			faceter, ok := r.(benchflix.Faceter)
+			if !ok {
+				t.Skip(reflect.TypeOf(r), "does not implement Faceter")
+			}
+
+			for _, record := range records[1:] {
+				movie, err := benchflix.NewMovie(record)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if err = r.Create(t.Context(), movie); err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+			}
+
+			for _, c := range facetCases {
+				facets, err := faceter.Facets(t.Context(), c.Query)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if facets.Count != c.Count {
+					t.Fatalf("%s: %s: invalid count: want %d got %d", reflect.TypeOf(r), c.Name, c.Count, facets.Count)
+				}
+
+				if c.Genres != "" && fmt.Sprint(facets.Genres) != c.Genres {
+					t.Fatal(reflect.TypeOf(r), c.Name, facets.Genres)
+				}
+
+				movies, err := r.Query(t.Context(), c.Query)
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				if int64(len(movies)) != facets.Count {
+					t.Fatalf("%s: %s: count differs from query: want %d got %d", reflect.TypeOf(r), c.Name, len(movies), facets.Count)
+				}
+
+				if references[c.Name] == "" {
+					references[c.Name] = fmt.Sprint(facets)
+				} else if fmt.Sprint(facets) != references[c.Name] {
+					t.Fatalf("%s: %s: facets differ from %s", reflect.TypeOf(r), c.Name, inits[0].Name)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFacets(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	do := func(faceter benchflix.Faceter, c FacetCase) {
+		facets, err := faceter.Facets(b.Context(), c.Query)
+		if err != nil {
+			b.Fatal(reflect.TypeOf(faceter), err)
+		}
+
+		if facets.Count != c.Count {
+			b.Fatalf("%s: %s: invalid count: want %d got %d", reflect.TypeOf(faceter), c.Name, c.Count, facets.Count)
+		}
+	}
+
+	for _, init := range inits {
+		r := init.New(b)
+
+		faceter, ok := r.(benchflix.Faceter)
+		if !ok {
+			continue
+		}
+
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if err = r.Create(b.Context(), movie); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		for _, c := range facetCases {
+			// Warmup
+			do(faceter, c)
+
+			b.Run(c.Name+"_"+init.Name, func(b *testing.B) {
+				s := countStatements()
+
+				for b.Loop() {
+					do(faceter, c)
+				}
+
+				s.report(b)
+			})
+		}
+	}
+}
+
+func BenchmarkCreateMany(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	movies := make([]benchflix.Movie, len(records)-1)
+
+	for i, record := range records[1:] {
+		movies[i], err = benchflix.NewMovie(record)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	sizes := []struct {
+		Name string
+		Num  int
+	}{
+		{"100", 100},
+		{"1000", 1000},
+		{"all", len(movies)},
+	}
+
+	for _, init := range inits {
+		for _, size := range sizes {
+			b.Run(size.Name+"_"+init.Name, func(b *testing.B) {
+				s := countStatements()
+
+				for b.Loop() {
+					b.StopTimer()
+
+					r := init.New(b)
+
+					bulk, ok := r.(benchflix.BulkCreator)
+					if !ok {
+						b.Skip(reflect.TypeOf(r), "does not implement BulkCreator")
+					}
+
+					b.StartTimer()
+
+					if err := bulk.CreateMany(b.Context(), movies[:size.Num]); err != nil {
+						b.Fatal(reflect.TypeOf(r), err)
+					}
+				}
+
+				s.report(b)
+			})
+		}
+	}
+}
+
+func Test_Pagination(t *testing.T) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sorts := []benchflix.Sort{
+		{},
+		{Field: benchflix.SortByRating, Descending: true},
+		{Field: benchflix.SortByAddedAt},
+	}
+
+	for _, init := range inits {
+		r := init.New(t)
+
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+
+			if err = r.Create(t.Context(), movie); err != nil {
+				t.Fatal(reflect.TypeOf(r), err)
+			}
+		}
+
+		for _, sort := range sorts {
+			query := benchflix.Query{
+				MinRating: 5,
+				Sort:      sort,
+				Limit:     250,
+			}
+
+			name := init.Name + "_" + cmp.Or(string(sort.Field), "default")
+			if sort.Descending {
+				name += "_desc"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				all, err := r.Query(t.Context(), benchflix.Query{MinRating: query.MinRating, Sort: query.Sort})
+				if err != nil {
+					t.Fatal(reflect.TypeOf(r), err)
+				}
+
+				var offset, keyset []benchflix.Movie
+
+				for page := query; ; page.Offset += page.Limit {
+					movies, err := r.Query(t.Context(), page)
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					if len(movies) == 0 {
+						break
+					}
+
+					offset = append(offset, movies...)
+				}
+
+				for page := query; ; {
+					movies, err := r.Query(t.Context(), page)
+					if err != nil {
+						t.Fatal(reflect.TypeOf(r), err)
+					}
+
+					if len(movies) == 0 {
+						break
+					}
+
+					keyset = append(keyset, movies...)
+
+					page.After = benchflix.NewCursor(movies[len(movies)-1])
+				}
+
+				if fmt.Sprint(offset) != fmt.Sprint(all) {
+					t.Fatalf("%s: offset pagination: want %d movies got %d", reflect.TypeOf(r), len(all), len(offset))
+				}
+
+				if fmt.Sprint(keyset) != fmt.Sprint(all) {
+					t.Fatalf("%s: keyset pagination: want %d movies got %d", reflect.TypeOf(r), len(all), len(keyset))
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkPagination(b *testing.B) {
+	file, err := os.Open("./movies.csv")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	do := func(r benchflix.Repository, query benchflix.Query, result string) {
+		movies, err := r.Query(b.Context(), query)
+		if err != nil {
+			b.Fatal(reflect.TypeOf(r), err)
+		}
+
+		if fmt.Sprint(movies) != result {
+			b.Fatal(reflect.TypeOf(r), query, movies)
+		}
+	}
+
+	for _, init := range inits {
+		r := init.New(b)
+
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if err = r.Create(b.Context(), movie); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		// The same page of 10 movies, once skipped to with OFFSET 5000 and once
+		// addressed by the cursor of the 5000th movie.
+		offset := benchflix.Query{Limit: 10, Offset: 5000}
+
+		last, err := r.Query(b.Context(), benchflix.Query{Limit: 1, Offset: offset.Offset - 1})
+		if err != nil || len(last) != 1 {
+			b.Fatal(reflect.TypeOf(r), err, last)
+		}
+
+		keyset := benchflix.Query{Limit: 10, After: benchflix.NewCursor(last[0])}
+
+		page, err := r.Query(b.Context(), offset)
+		if err != nil {
+			b.Fatal(reflect.TypeOf(r), err)
+		}
+
+		result := fmt.Sprint(page)
+
+		// Warmup
+		do(r, keyset, result)
+
+		b.Run("Offset_"+init.Name, func(b *testing.B) {
+			s := countStatements()
+
+			for b.Loop() {
+				do(r, offset, result)
+			}
+
+			s.report(b)
+		})
+
+		b.Run("Keyset_"+init.Name, func(b *testing.B) {
+			s := countStatements()
+
+			for b.Loop() {
+				do(r, keyset, result)
+			}
+
+			s.report(b)
+		})
+	}
 }
@@ -0,0 +1,426 @@
+package bobflix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/scan"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+)
+
+func init() {
+	drivers.Register("bob", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	db, err := bob.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.ExecContext(context.Background(),
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: db,
+	}, nil
+}
+
+type Repository struct {
+	DB bob.DB
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := sqlite.Delete(
+		dm.From("movies"),
+		dm.Where(sqlite.Quote("id").EQ(sqlite.Arg(id))),
+	).Exec(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = sqlite.Insert(
+		im.Into("movies", "id", "title", "added_at", "rating"),
+		im.Values(sqlite.Arg(movie.ID, movie.Title, movie.AddedAt, movie.Rating)),
+	).Exec(ctx, tx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := sqlite.Update(
+		um.Table("movies"),
+		um.SetCol("title").ToArg(movie.Title),
+		um.SetCol("added_at").ToArg(movie.AddedAt),
+		um.SetCol("rating").ToArg(movie.Rating),
+		um.Where(sqlite.Quote("id").EQ(sqlite.Arg(movie.ID))),
+	).Exec(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+		_, err = sqlite.Delete(
+			dm.From(table),
+			dm.Where(sqlite.Quote("movie_id").EQ(sqlite.Arg(movie.ID))),
+		).Exec(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func insertRelations(ctx context.Context, tx bob.Tx, movie benchflix.Movie) error {
+	if err := insertNames(ctx, tx, "people", "movie_directors", "person_id", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "people", "movie_actors", "person_id", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "countries", "movie_countries", "country_id", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(ctx, tx, "genres", "movie_genres", "genre_id", movie.ID, movie.Genres)
+}
+
+// insertNames upserts names into table and links their IDs to the movie.
+func insertNames(ctx context.Context, tx bob.Tx, table, links, column string, movieID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	insert := sqlite.Insert(
+		im.Into(table, "name"),
+		im.OnConflict("name").DoUpdate(im.SetExcluded("name")),
+		im.Returning("id"),
+	)
+
+	for _, name := range names {
+		insert.Apply(im.Values(sqlite.Arg(name)))
+	}
+
+	ids, err := bob.All(ctx, tx, insert, scan.SingleColumnMapper[int64])
+	if err != nil {
+		return err
+	}
+
+	link := sqlite.Insert(im.Into(links, "movie_id", column))
+
+	for _, id := range ids {
+		link.Apply(im.Values(sqlite.Arg(movieID, id)))
+	}
+
+	_, err = link.Exec(ctx, tx)
+
+	return err
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) []bob.Mod[*dialect.SelectQuery] {
+	var mods []bob.Mod[*dialect.SelectQuery]
+
+	if query.Search != "" {
+		mods = append(mods, sm.Where(sqlite.Or(
+			exists("movie_directors", "people", "person_id",
+				sqlite.Raw("INSTR(people.name, ?) > 0", query.Search)),
+			exists("movie_actors", "people", "person_id",
+				sqlite.Raw("INSTR(people.name, ?) > 0", query.Search)),
+		)))
+	}
+
+	if query.Genre != "" {
+		mods = append(mods, sm.Where(exists("movie_genres", "genres", "genre_id",
+			sqlite.Quote("genres", "name").EQ(sqlite.Arg(query.Genre)))))
+	}
+
+	if query.Country != "" {
+		mods = append(mods, sm.Where(exists("movie_countries", "countries", "country_id",
+			sqlite.Quote("countries", "name").EQ(sqlite.Arg(query.Country)))))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		mods = append(mods, sm.Where(sqlite.Quote("movies", "added_at").LT(sqlite.Arg(query.AddedBefore))))
+	}
+
+	if !query.AddedAfter.IsZero() {
+		mods = append(mods, sm.Where(sqlite.Quote("movies", "added_at").GT(sqlite.Arg(query.AddedAfter))))
+	}
+
+	if query.MinRating > 0 {
+		mods = append(mods, sm.Where(sqlite.Quote("movies", "rating").GTE(sqlite.Arg(query.MinRating))))
+	}
+
+	if query.MaxRating > 0 {
+		mods = append(mods, sm.Where(sqlite.Quote("movies", "rating").LTE(sqlite.Arg(query.MaxRating))))
+	}
+
+	return mods
+}
+
+// exists matches movies linked to a row of table that satisfies condition.
+func exists(links, table, column string, condition bob.Expression) bob.Expression {
+	return sqlite.Raw("EXISTS ?", sqlite.Select(
+		sm.Columns(sqlite.Raw("1")),
+		sm.From(links),
+		sm.InnerJoin(table).On(sqlite.Quote(table, "id").EQ(sqlite.Quote(links, column))),
+		sm.Where(sqlite.Quote(links, "movie_id").EQ(sqlite.Quote("movies", "id"))),
+		sm.Where(condition),
+	))
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, table, column, alias string) bob.Expression {
+	return sqlite.Raw(fmt.Sprintf(
+		`(SELECT json_group_array(%[2]s.name ORDER BY %[2]s.name) FROM %[1]s JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s WHERE %[1]s.movie_id = movies.id) AS %[4]s`,
+		links, table, column, alias,
+	))
+}
+
+var columns = sm.Columns(
+	sqlite.Quote("movies", "id"),
+	sqlite.Quote("movies", "title"),
+	sqlite.Quote("movies", "added_at"),
+	sqlite.Quote("movies", "rating"),
+	aggregate("movie_directors", "people", "person_id", "directors"),
+	aggregate("movie_actors", "people", "person_id", "actors"),
+	aggregate("movie_countries", "countries", "country_id", "countries"),
+	aggregate("movie_genres", "genres", "genre_id", "genres"),
+)
+
+// Names scans a JSON array aggregated with json_group_array.
+type Names []string
+
+func (n *Names) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*n = nil
+
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), (*[]string)(n))
+	case []byte:
+		return json.Unmarshal(src, (*[]string)(n))
+	default:
+		return fmt.Errorf("bobflix: cannot scan %T into Names", src)
+	}
+}
+
+type Movie struct {
+	ID        int64     `db:"id"`
+	Title     string    `db:"title"`
+	AddedAt   time.Time `db:"added_at"`
+	Rating    float64   `db:"rating"`
+	Directors Names     `db:"directors"`
+	Actors    Names     `db:"actors"`
+	Countries Names     `db:"countries"`
+	Genres    Names     `db:"genres"`
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	q := sqlite.Select(columns, sm.From("movies"))
+
+	q.Apply(filter(query)...)
+
+	if query.After != nil {
+		keyset := sqlite.Group(sqlite.Quote("movies", column), sqlite.Quote("movies", "id"))
+		cursor := sqlite.ArgGroup(query.After.Value(query.Sort.Field), query.After.ID)
+
+		if query.Sort.Descending {
+			q.Apply(sm.Where(keyset.LT(cursor)))
+		} else {
+			q.Apply(sm.Where(keyset.GT(cursor)))
+		}
+	}
+
+	if query.Sort.Descending {
+		q.Apply(
+			sm.OrderBy(sqlite.Quote("movies", column)).Desc(),
+			sm.OrderBy(sqlite.Quote("movies", "id")).Desc(),
+		)
+	} else {
+		q.Apply(
+			sm.OrderBy(sqlite.Quote("movies", column)).Asc(),
+			sm.OrderBy(sqlite.Quote("movies", "id")).Asc(),
+		)
+	}
+
+	if query.Limit > 0 {
+		q.Apply(sm.Limit(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT.
+			q.Apply(sm.Limit(-1))
+		}
+
+		q.Apply(sm.Offset(query.Offset))
+	}
+
+	movies, err := bob.All(ctx, r.DB, q, scan.StructMapper[Movie]())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i] = ConvertMovie(movie)
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	movie, err := bob.One(ctx, r.DB, sqlite.Select(
+		columns,
+		sm.From("movies"),
+		sm.Where(sqlite.Quote("movies", "id").EQ(sqlite.Arg(id))),
+	), scan.StructMapper[Movie]())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	return ConvertMovie(movie), nil
+}
+
+func ConvertMovie(movie Movie) benchflix.Movie {
+	return benchflix.Movie{
+		ID:        movie.ID,
+		Title:     movie.Title,
+		AddedAt:   movie.AddedAt,
+		Rating:    movie.Rating,
+		Directors: movie.Directors,
+		Actors:    movie.Actors,
+		Countries: movie.Countries,
+		Genres:    movie.Genres,
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"iter"
 	"math"
+	"slices"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 )
 
 type Movie struct {
@@ -88,13 +92,32 @@ type MovieGenre struct {
 	Genre *Genre `bun:"rel:belongs-to,join:genre_id=id"`
 }
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
+func init() {
+	drivers.Register("bun", NewRepository)
+}
+
+// Dialect is the SQLite dialect of bun, which inlines the arguments into the
+// statement. SQLite ends a string literal at NUL, so strings that contain one
+// are written as a blob literal and cast to text.
+type Dialect struct {
+	*sqlitedialect.Dialect
+}
+
+func (d Dialect) AppendString(b []byte, s string) []byte {
+	if !strings.ContainsRune(s, 0) {
+		return d.Dialect.AppendString(b, s)
+	}
+
+	return fmt.Appendf(b, "CAST(X'%x' AS TEXT)", s)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
 	sqldb, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	db := bun.NewDB(sqldb, sqlitedialect.New())
+	db := bun.NewDB(sqldb, Dialect{sqlitedialect.New()})
 
 	db.RegisterModel(
 		(*MovieDirector)(nil),
@@ -104,48 +127,48 @@ func NewRepository(driverName, dataSourceName string) benchflix.Repository {
 	)
 
 	if _, err = db.NewCreateTable().Model((*Movie)(nil)).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*Person)(nil)).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*Country)(nil)).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*Genre)(nil)).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*MovieDirector)(nil)).
 		ForeignKey(`(movie_id) REFERENCES movies(id) ON DELETE CASCADE`).
 		ForeignKey(`(person_id) REFERENCES people(id) ON DELETE CASCADE`).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*MovieActor)(nil)).
 		ForeignKey(`(movie_id) REFERENCES movies(id) ON DELETE CASCADE`).
 		ForeignKey(`(person_id) REFERENCES people(id) ON DELETE CASCADE`).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*MovieCountry)(nil)).
 		ForeignKey(`(movie_id) REFERENCES movies(id) ON DELETE CASCADE`).
 		ForeignKey(`(country_id) REFERENCES countries(id) ON DELETE CASCADE`).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = db.NewCreateTable().Model((*MovieGenre)(nil)).
 		ForeignKey(`(movie_id) REFERENCES movies(id) ON DELETE CASCADE`).
 		ForeignKey(`(genre_id) REFERENCES genres(id) ON DELETE CASCADE`).Exec(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	return Repository{
 		DB: db,
-	}
+	}, nil
 }
 
 type Repository struct {
@@ -153,12 +176,24 @@ type Repository struct {
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	_, err := r.DB.NewDelete().Model(&Movie{}).Where("id = ?", id).Exec(ctx)
+	result, err := r.DB.NewDelete().Model(&Movie{}).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return err
+	}
 
-	return err
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
-func (r Repository) Create(ctx context.Context, movie benchflix.Movie) error {
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -172,15 +207,227 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) error {
 		}
 	}()
 
-	if _, err := tx.NewInsert().Model(&Movie{
+	if _, err = tx.NewInsert().Model(&Movie{
 		ID:      movie.ID,
 		Title:   movie.Title,
 		AddedAt: movie.AddedAt,
 		Rating:  movie.Rating,
 	}).Exec(ctx); err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := tx.NewUpdate().Model(&Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}).Column("title", "added_at", "rating").WherePK().Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	if _, err = tx.NewDelete().Model((*MovieDirector)(nil)).Where("movie_id = ?", movie.ID).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err = tx.NewDelete().Model((*MovieActor)(nil)).Where("movie_id = ?", movie.ID).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err = tx.NewDelete().Model((*MovieCountry)(nil)).Where("movie_id = ?", movie.ID).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err = tx.NewDelete().Model((*MovieGenre)(nil)).Where("movie_id = ?", movie.ID).Exec(ctx); err != nil {
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+const batchSize = 1000
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
 		return err
 	}
 
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var people, countries, genres []string
+
+	for batch := range slices.Chunk(movies, batchSize) {
+		list := make([]Movie, len(batch))
+
+		for i, movie := range batch {
+			list[i] = Movie{
+				ID:      movie.ID,
+				Title:   movie.Title,
+				AddedAt: movie.AddedAt,
+				Rating:  movie.Rating,
+			}
+
+			people = append(append(people, movie.Directors...), movie.Actors...)
+			countries = append(countries, movie.Countries...)
+			genres = append(genres, movie.Genres...)
+		}
+
+		if _, err = tx.NewInsert().Model(&list).Exec(ctx); err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+	}
+
+	personIDs := map[string]int64{}
+
+	for batch := range slices.Chunk(benchflix.Unique(people), batchSize) {
+		list := make([]Person, len(batch))
+
+		for i, name := range batch {
+			list[i] = Person{Name: name}
+		}
+
+		if _, err = tx.NewInsert().Model(&list).On("CONFLICT (name) DO UPDATE").
+			Set("name = EXCLUDED.name").Returning("id, name").Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, p := range list {
+			personIDs[p.Name] = p.ID
+		}
+	}
+
+	countryIDs := map[string]int64{}
+
+	for batch := range slices.Chunk(benchflix.Unique(countries), batchSize) {
+		list := make([]Country, len(batch))
+
+		for i, name := range batch {
+			list[i] = Country{Name: name}
+		}
+
+		if _, err = tx.NewInsert().Model(&list).On("CONFLICT (name) DO UPDATE").
+			Set("name = EXCLUDED.name").Returning("id, name").Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, c := range list {
+			countryIDs[c.Name] = c.ID
+		}
+	}
+
+	genreIDs := map[string]int64{}
+
+	for batch := range slices.Chunk(benchflix.Unique(genres), batchSize) {
+		list := make([]Genre, len(batch))
+
+		for i, name := range batch {
+			list[i] = Genre{Name: name}
+		}
+
+		if _, err = tx.NewInsert().Model(&list).On("CONFLICT (name) DO UPDATE").
+			Set("name = EXCLUDED.name").Returning("id, name").Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, g := range list {
+			genreIDs[g.Name] = g.ID
+		}
+	}
+
+	var (
+		movieDirectors []MovieDirector
+		movieActors    []MovieActor
+		movieCountries []MovieCountry
+		movieGenres    []MovieGenre
+	)
+
+	for _, movie := range movies {
+		for _, name := range movie.Directors {
+			movieDirectors = append(movieDirectors, MovieDirector{MovieID: movie.ID, PersonID: personIDs[name]})
+		}
+
+		for _, name := range movie.Actors {
+			movieActors = append(movieActors, MovieActor{MovieID: movie.ID, PersonID: personIDs[name]})
+		}
+
+		for _, name := range movie.Countries {
+			movieCountries = append(movieCountries, MovieCountry{MovieID: movie.ID, CountryID: countryIDs[name]})
+		}
+
+		for _, name := range movie.Genres {
+			movieGenres = append(movieGenres, MovieGenre{MovieID: movie.ID, GenreID: genreIDs[name]})
+		}
+	}
+
+	for batch := range slices.Chunk(movieDirectors, batchSize) {
+		if _, err = tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(movieActors, batchSize) {
+		if _, err = tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(movieCountries, batchSize) {
+		if _, err = tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(movieGenres, batchSize) {
+		if _, err = tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertRelations(ctx context.Context, tx bun.Tx, movie benchflix.Movie) (err error) {
 	directorIDs := make([]int64, len(movie.Directors))
 
 	for i, d := range movie.Directors {
@@ -288,7 +535,7 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) error {
 func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
 	var movies []Movie
 
-	q := r.DB.NewSelect().Model(&movies).
+	q, err := r.paginate(r.DB.NewSelect().Model(&movies).
 		Relation("Directors", func(sq *bun.SelectQuery) *bun.SelectQuery {
 			return sq.Order("name ASC")
 		}).
@@ -300,13 +547,211 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		}).
 		Relation("Genres", func(sq *bun.SelectQuery) *bun.SelectQuery {
 			return sq.Order("name ASC")
-		}).
-		Order("title ASC")
+		}), query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, one := range movies {
+		movie := benchflix.Movie{
+			ID:        one.ID,
+			Title:     one.Title,
+			AddedAt:   one.AddedAt,
+			Rating:    one.Rating,
+			Directors: make([]string, len(one.Directors)),
+			Actors:    make([]string, len(one.Actors)),
+			Countries: make([]string, len(one.Countries)),
+			Genres:    make([]string, len(one.Genres)),
+		}
+
+		for i, d := range one.Directors {
+			movie.Directors[i] = d.Name
+		}
+
+		for i, d := range one.Actors {
+			movie.Actors[i] = d.Name
+		}
+
+		for i, d := range one.Countries {
+			movie.Countries[i] = d.Name
+		}
+
+		for i, d := range one.Genres {
+			movie.Genres[i] = d.Name
+		}
+
+		result[i] = movie
+	}
+
+	return result, nil
+}
+
+// MovieRow is a movie with its relations aggregated into JSON array
+// columns. Relations are not loaded by Rows, so Stream selects it instead.
+type MovieRow struct {
+	ID                                   int64
+	Title                                string
+	AddedAt                              time.Time
+	Rating                               float64
+	Directors, Actors, Countries, Genres []string
+}
+
+func (r Repository) Stream(ctx context.Context, query benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		q, err := r.paginate(r.DB.NewSelect().Model((*Movie)(nil)).
+			Column("movie.id", "movie.title", "movie.added_at", "movie.rating").
+			ColumnExpr(`(
+				SELECT json_group_array(people.name ORDER BY people.name)
+				FROM movie_directors
+				JOIN people ON people.id = movie_directors.person_id
+				WHERE movie_directors.movie_id = movie.id
+			) AS directors`).
+			ColumnExpr(`(
+				SELECT json_group_array(people.name ORDER BY people.name)
+				FROM movie_actors
+				JOIN people ON people.id = movie_actors.person_id
+				WHERE movie_actors.movie_id = movie.id
+			) AS actors`).
+			ColumnExpr(`(
+				SELECT json_group_array(countries.name ORDER BY countries.name)
+				FROM movie_countries
+				JOIN countries ON countries.id = movie_countries.country_id
+				WHERE movie_countries.movie_id = movie.id
+			) AS countries`).
+			ColumnExpr(`(
+				SELECT json_group_array(genres.name ORDER BY genres.name)
+				FROM movie_genres
+				JOIN genres ON genres.id = movie_genres.genre_id
+				WHERE movie_genres.movie_id = movie.id
+			) AS genres`), query)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		rows, err := q.Rows(ctx)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var row MovieRow
+
+			if err = r.DB.ScanRow(ctx, rows, &row); err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			movie := benchflix.Movie{
+				ID:        row.ID,
+				Title:     row.Title,
+				AddedAt:   row.AddedAt,
+				Rating:    row.Rating,
+				Directors: row.Directors,
+				Actors:    row.Actors,
+				Countries: row.Countries,
+				Genres:    row.Genres,
+			}
+
+			if !yield(movie, nil) {
+				return
+			}
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			yield(benchflix.Movie{}, err)
+		}
+	}
+}
+
+// paginate filters, sorts and limits a select on the movies table.
+func (r Repository) paginate(q *bun.SelectQuery, query benchflix.Query) (*bun.SelectQuery, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	q = q.OrderExpr("movie.? ?, movie.id ?", bun.Ident(column), bun.Safe(query.Sort.Direction()), bun.Safe(query.Sort.Direction()))
 
 	if query.Limit > 0 && query.Limit < math.MaxInt {
 		q = q.Limit(int(query.Limit))
+	} else if query.Offset > 0 {
+		// SQLite does not accept OFFSET without LIMIT and bun cannot write LIMIT -1.
+		q = q.Limit(math.MaxInt32)
 	}
 
+	if query.Offset > 0 && query.Offset < math.MaxInt {
+		q = q.Offset(int(query.Offset))
+	}
+
+	q = r.filter(q, query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		q = q.Where("(movie.?, movie.id) ? (?, ?)",
+			bun.Ident(column), bun.Safe(operator), query.After.Value(query.Sort.Field), query.After.ID)
+	}
+
+	return q, nil
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	var facets benchflix.Facets
+
+	count, err := r.filter(r.DB.NewSelect().Model((*Movie)(nil)), query).Count(ctx)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	facets.Count = int64(count)
+
+	ids := r.filter(r.DB.NewSelect().Model((*Movie)(nil)).Column("movie.id"), query)
+
+	err = r.DB.NewSelect().
+		TableExpr("movie_genres").
+		ColumnExpr("genres.name AS name, COUNT(*) AS count").
+		Join("JOIN genres ON genres.id = movie_genres.genre_id").
+		Where("movie_genres.movie_id IN (?)", ids).
+		Group("genres.name").
+		OrderExpr("count DESC, genres.name ASC").
+		Scan(ctx, &facets.Genres)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = r.DB.NewSelect().
+		TableExpr("movie_countries").
+		ColumnExpr("countries.name AS name, COUNT(*) AS count").
+		Join("JOIN countries ON countries.id = movie_countries.country_id").
+		Where("movie_countries.movie_id IN (?)", ids).
+		Group("countries.name").
+		OrderExpr("count DESC, countries.name ASC").
+		Scan(ctx, &facets.Countries)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	return facets, nil
+}
+
+// filter adds the conditions of query to a select on the movies table.
+func (r Repository) filter(q *bun.SelectQuery, query benchflix.Query) *bun.SelectQuery {
 	if query.Search != "" {
 		q = q.Where("(EXISTS (?) OR EXISTS (?))",
 			r.DB.NewSelect().
@@ -358,44 +803,7 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		q = q.Where("rating <= ?", query.MaxRating)
 	}
 
-	if err := q.Scan(ctx); err != nil {
-		return nil, err
-	}
-
-	result := make([]benchflix.Movie, len(movies))
-
-	for i, one := range movies {
-		movie := benchflix.Movie{
-			ID:        one.ID,
-			Title:     one.Title,
-			AddedAt:   one.AddedAt,
-			Rating:    one.Rating,
-			Directors: make([]string, len(one.Directors)),
-			Actors:    make([]string, len(one.Actors)),
-			Countries: make([]string, len(one.Countries)),
-			Genres:    make([]string, len(one.Genres)),
-		}
-
-		for i, d := range one.Directors {
-			movie.Directors[i] = d.Name
-		}
-
-		for i, d := range one.Actors {
-			movie.Actors[i] = d.Name
-		}
-
-		for i, d := range one.Countries {
-			movie.Countries[i] = d.Name
-		}
-
-		for i, d := range one.Genres {
-			movie.Genres[i] = d.Name
-		}
-
-		result[i] = movie
-	}
-
-	return result, nil
+	return q
 }
 
 func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
@@ -415,6 +823,10 @@ func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error)
 			return sq.Order("name ASC")
 		}).
 		Where("id = ?", id).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
@@ -5,19 +5,22 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/opts"
 	"github.com/go-echarts/snapshot-chromedp/render"
+	benchflix "github.com/wroge/bench-flix"
+	_ "github.com/wroge/bench-flix/all"
 	"golang.org/x/tools/benchmark/parse"
 )
 
 func main() {
-	unit := flag.String("unit", "NsPerOp", "Benchmark Unit: NsPerOp | AllocedBytesPerOp | AllocsPerOp")
+	unit := flag.String("unit", "NsPerOp", "Benchmark Unit: NsPerOp | AllocedBytesPerOp | AllocsPerOp | p50-ns | p95-ns | p99-ns | max-ns | queries/op | rows/op")
 	benchmark := flag.String("benchmark", "BenchmarkQuery", "Benchmark Name")
 	variants := flag.String("variants", "", "Benchmark Variants")
-	frameworks := flag.String("frameworks", "sql,gorm,sqlt,ent,sqlc,bun,sqlx", "Frameworks")
+	frameworks := flag.String("frameworks", strings.Join(benchflix.Implementations(), ","), "Frameworks")
 
 	flag.Parse()
 
@@ -40,7 +43,13 @@ func main() {
 		}
 
 		name := strings.TrimPrefix(b.Name, "Benchmark"+*benchmark+"/")
-		name = strings.TrimSuffix(name, "-12")
+
+		// Strip the GOMAXPROCS suffix, e.g. -12 or -8 when run with -cpu.
+		if i := strings.LastIndex(name, "-"); i >= 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
 
 		parts := strings.SplitN(name, "_", 2)
 
@@ -66,6 +75,8 @@ func main() {
 			data[variant][framework] = float64(b.AllocedBytesPerOp)
 		case "AllocsPerOp":
 			data[variant][framework] = float64(b.AllocsPerOp)
+		default:
+			data[variant][framework] = metric(line, *unit)
 		}
 	}
 
@@ -98,7 +109,7 @@ func main() {
 		chart.AddSeries(variant, values)
 	}
 
-	filename := fmt.Sprintf("%s_%s", *benchmark, *unit)
+	filename := fmt.Sprintf("%s_%s", *benchmark, strings.ReplaceAll(*unit, "/op", "PerOp"))
 
 	if *variants != "" {
 		filename += "_" + strings.ReplaceAll(*variants, ",", "")
@@ -110,3 +121,24 @@ func main() {
 
 	fmt.Printf("Chart written to %s\n", filename)
 }
+
+// metric returns the value of a custom unit reported with b.ReportMetric,
+// which parse.ParseLine ignores.
+func metric(line, unit string) float64 {
+	fields := strings.Fields(line)
+
+	for i := 3; i < len(fields); i += 2 {
+		if fields[i] != unit {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(fields[i-1], 64)
+		if err != nil {
+			panic(err)
+		}
+
+		return value
+	}
+
+	return 0
+}
@@ -3,16 +3,21 @@ package main
 import (
 	"context"
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	benchflix "github.com/wroge/bench-flix"
-	bobflix "github.com/wroge/bench-flix/bob-flix"
+	_ "github.com/wroge/bench-flix/all"
 )
 
 func main() {
+	only := flag.String("only", strings.Join(benchflix.Implementations(), ","), "Implementations")
+
+	flag.Parse()
+
 	ctx := context.Background()
-	r := bobflix.NewRepository("sqlite3", ":memory:?_fk=1")
 
 	file, err := os.Open("./movies.csv")
 	if err != nil {
@@ -24,34 +29,41 @@ func main() {
 		panic(err)
 	}
 
-	for _, record := range records[1:] {
-		movie, err := benchflix.NewMovie(record)
+	for _, name := range strings.Split(*only, ",") {
+		r, err := benchflix.Open(name, ":memory:?_fk=1")
 		if err != nil {
 			panic(err)
 		}
 
-		if err = r.Create(ctx, movie); err != nil {
-			panic(err)
+		for _, record := range records[1:] {
+			movie, err := benchflix.NewMovie(record)
+			if err != nil {
+				panic(err)
+			}
+
+			if err = r.Create(ctx, movie); err != nil {
+				panic(err)
+			}
 		}
-	}
 
-	movies, err := r.Query(ctx, benchflix.Query{
-		MinRating: 5,
-		Limit:     1,
-		// Search:      "Affleck",
-		// Country:     "United Kingdom",
-		// Genre:       "Drama",
-		// AddedAfter:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
-		// AddedBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
-		// MinRating:   4,
-		// MaxRating:   8,
-		// Limit:       1,
-	})
-	if err != nil {
-		panic(err)
-	}
+		movies, err := r.Query(ctx, benchflix.Query{
+			MinRating: 5,
+			Limit:     1,
+			// Search:      "Affleck",
+			// Country:     "United Kingdom",
+			// Genre:       "Drama",
+			// AddedAfter:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			// AddedBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			// MinRating:   4,
+			// MaxRating:   8,
+			// Limit:       1,
+		})
+		if err != nil {
+			panic(err)
+		}
 
-	fmt.Println(movies)
+		fmt.Println(name, movies)
 
-	fmt.Println(r.Read(ctx, 1310741))
+		fmt.Println(r.Read(ctx, 1310741))
+	}
 }
@@ -0,0 +1,452 @@
+package dbrflix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+)
+
+func init() {
+	drivers.Register("dbr", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	sqldb, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// dbr.Open picks the dialect by the driver name and knows only sqlite3 and
+	// sqlite, so the connection is set up by hand.
+	conn := &dbr.Connection{
+		DB:            sqldb,
+		Dialect:       Dialect{dialect.SQLite3},
+		EventReceiver: &dbr.NullEventReceiver{},
+	}
+
+	_, err = conn.Exec(
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: conn,
+	}, nil
+}
+
+// Dialect is the SQLite dialect of dbr. SQLite ends a string literal at NUL,
+// so strings that contain one are written as a blob literal and cast to text.
+type Dialect struct {
+	dbr.Dialect
+}
+
+func (d Dialect) EncodeString(s string) string {
+	if !strings.ContainsRune(s, 0) {
+		return d.Dialect.EncodeString(s)
+	}
+
+	return fmt.Sprintf("CAST(X'%x' AS TEXT)", s)
+}
+
+// Repository runs every statement in a new session. dbr always interpolates
+// the arguments into the SQL, so times are written and compared as text in
+// the format of the dialect.
+type Repository struct {
+	DB *dbr.Connection
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB.NewSession(nil).
+		DeleteFrom("movies").
+		Where(dbr.Eq("id", id)).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.NewSession(nil).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.InsertInto("movies").
+		Columns("id", "title", "added_at", "rating").
+		Values(movie.ID, movie.Title, movie.AddedAt, movie.Rating).
+		ExecContext(ctx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.NewSession(nil).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := tx.Update("movies").
+		Set("title", movie.Title).
+		Set("added_at", movie.AddedAt).
+		Set("rating", movie.Rating).
+		Where(dbr.Eq("id", movie.ID)).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+		_, err = tx.DeleteFrom(table).
+			Where(dbr.Eq("movie_id", movie.ID)).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func insertRelations(ctx context.Context, tx *dbr.Tx, movie benchflix.Movie) error {
+	if err := insertNames(ctx, tx, "people", "movie_directors", "person_id", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "people", "movie_actors", "person_id", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "countries", "movie_countries", "country_id", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(ctx, tx, "genres", "movie_genres", "genre_id", movie.ID, movie.Genres)
+}
+
+// insertNames upserts names into table and links their IDs to the movie. The
+// InsertStmt of dbr has no ON CONFLICT clause, so the upsert is plain SQL.
+func insertNames(ctx context.Context, tx *dbr.Tx, table, links, column string, movieID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(names))
+
+	for i, name := range names {
+		args[i] = name
+	}
+
+	var ids []int64
+
+	err := tx.InsertBySql(
+		fmt.Sprintf(
+			`INSERT INTO %s (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = excluded.name RETURNING id`,
+			table, strings.Repeat(",(?)", len(names))[1:],
+		),
+		args...,
+	).LoadContext(ctx, &ids)
+	if err != nil {
+		return err
+	}
+
+	insert := tx.InsertInto(links).Columns("movie_id", column)
+
+	for _, id := range ids {
+		insert = insert.Values(movieID, id)
+	}
+
+	_, err = insert.ExecContext(ctx)
+
+	return err
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) []dbr.Builder {
+	var conditions []dbr.Builder
+
+	if query.Search != "" {
+		conditions = append(conditions, dbr.Or(
+			exists("movie_directors", "people", "person_id", "INSTR(people.name, ?) > 0", query.Search),
+			exists("movie_actors", "people", "person_id", "INSTR(people.name, ?) > 0", query.Search),
+		))
+	}
+
+	if query.Genre != "" {
+		conditions = append(conditions, exists("movie_genres", "genres", "genre_id", "genres.name = ?", query.Genre))
+	}
+
+	if query.Country != "" {
+		conditions = append(conditions, exists("movie_countries", "countries", "country_id", "countries.name = ?", query.Country))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		conditions = append(conditions, dbr.Lt("movies.added_at", query.AddedBefore))
+	}
+
+	if !query.AddedAfter.IsZero() {
+		conditions = append(conditions, dbr.Gt("movies.added_at", query.AddedAfter))
+	}
+
+	if query.MinRating > 0 {
+		conditions = append(conditions, dbr.Gte("movies.rating", query.MinRating))
+	}
+
+	if query.MaxRating > 0 {
+		conditions = append(conditions, dbr.Lte("movies.rating", query.MaxRating))
+	}
+
+	return conditions
+}
+
+// exists matches movies linked to a row of table that satisfies condition.
+func exists(links, table, column, condition string, arg any) dbr.Builder {
+	return dbr.Expr(fmt.Sprintf(
+		`EXISTS (
+			SELECT 1 FROM %[1]s
+			JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s
+			WHERE %[1]s.movie_id = movies.id AND %[4]s
+		)`,
+		links, table, column, condition,
+	), arg)
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, table, column, alias string) string {
+	return fmt.Sprintf(
+		`(
+			SELECT json_group_array(%[2]s.name ORDER BY %[2]s.name)
+			FROM %[1]s
+			JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s
+			WHERE %[1]s.movie_id = movies.id
+		) AS %[4]s`,
+		links, table, column, alias,
+	)
+}
+
+var columns = []string{
+	"movies.id",
+	"movies.title",
+	"movies.added_at",
+	"movies.rating",
+	aggregate("movie_directors", "people", "person_id", "directors"),
+	aggregate("movie_actors", "people", "person_id", "actors"),
+	aggregate("movie_countries", "countries", "country_id", "countries"),
+	aggregate("movie_genres", "genres", "genre_id", "genres"),
+}
+
+type Movie struct {
+	ID        int64     `db:"id"`
+	Title     string    `db:"title"`
+	AddedAt   time.Time `db:"added_at"`
+	Rating    float64   `db:"rating"`
+	Directors []byte    `db:"directors"`
+	Actors    []byte    `db:"actors"`
+	Countries []byte    `db:"countries"`
+	Genres    []byte    `db:"genres"`
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := r.DB.NewSession(nil).
+		Select(columns...).
+		From("movies")
+
+	for _, condition := range filter(query) {
+		stmt = stmt.Where(condition)
+	}
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		stmt = stmt.Where(
+			fmt.Sprintf("(movies.%s, movies.id) %s (?, ?)", column, operator),
+			query.After.Value(query.Sort.Field), query.After.ID,
+		)
+	}
+
+	stmt = stmt.
+		OrderDir("movies."+column, !query.Sort.Descending).
+		OrderDir("movies.id", !query.Sort.Descending)
+
+	if query.Limit > 0 {
+		stmt = stmt.Limit(query.Limit)
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT.
+			stmt = stmt.Limit(math.MaxInt64)
+		}
+
+		stmt = stmt.Offset(query.Offset)
+	}
+
+	var movies []Movie
+
+	if _, err = stmt.LoadContext(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i], err = ConvertMovie(movie)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	err := r.DB.NewSession(nil).
+		Select(columns...).
+		From("movies").
+		Where(dbr.Eq("movies.id", id)).
+		LoadOneContext(ctx, &movie)
+	if err != nil {
+		if errors.Is(err, dbr.ErrNotFound) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	return ConvertMovie(movie)
+}
+
+// ConvertMovie decodes the names aggregated with json_group_array.
+func ConvertMovie(movie Movie) (benchflix.Movie, error) {
+	result := benchflix.Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+
+	if err := json.Unmarshal(movie.Directors, &result.Directors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Actors, &result.Actors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Countries, &result.Countries); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Genres, &result.Genres); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return result, nil
+}
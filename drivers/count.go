@@ -0,0 +1,419 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Count is the number of statements executed and rows read through the
+// counting drivers, and the statements they prepared.
+type Count struct {
+	Queries  int64
+	Rows     int64
+	Prepares int64
+}
+
+// Sub returns the statements and rows counted since start.
+func (c Count) Sub(start Count) Count {
+	return Count{
+		Queries:  c.Queries - start.Queries,
+		Rows:     c.Rows - start.Rows,
+		Prepares: c.Prepares - start.Prepares,
+	}
+}
+
+var queries, rows, prepares atomic.Int64
+
+// executed counts a statement and records it if a Trace is running.
+func executed(conn driver.Conn, query string, args []driver.NamedValue) {
+	queries.Add(1)
+
+	record(conn, query, args)
+}
+
+// Executed counts a statement of an implementation that does not use
+// database/sql, like zombiezen, and records it if a Trace is running. The
+// arguments are recorded as they are passed, with ordinals from 1. queryPlan
+// runs EXPLAIN QUERY PLAN on the connection of the statement and is only
+// called by Explain.
+func Executed(query string, args []any, queryPlan func() ([]PlanRow, error)) {
+	queries.Add(1)
+
+	if !traced.Load() {
+		return
+	}
+
+	named := make([]driver.NamedValue, len(args))
+
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	add(query, named, func() []string {
+		return lines(queryPlan())
+	})
+}
+
+// RowsRead counts n rows read by an implementation that does not use
+// database/sql.
+func RowsRead(n int64) {
+	rows.Add(n)
+}
+
+// Counted returns the statements and rows counted so far by all databases
+// opened through a counting driver and by Executed and RowsRead. Prepared
+// statements are counted in Prepares, beginning and ending a transaction is not
+// counted.
+func Counted() Count {
+	return Count{
+		Queries:  queries.Load(),
+		Rows:     rows.Load(),
+		Prepares: prepares.Load(),
+	}
+}
+
+var (
+	countingMu sync.Mutex
+	counting   = map[string]string{}
+)
+
+// Counting returns the name of a database/sql driver that wraps driverName,
+// counts its statements and rows and records them for Trace. The driver is
+// registered on first use.
+func Counting(driverName string) string {
+	countingMu.Lock()
+	defer countingMu.Unlock()
+
+	if name, ok := counting[driverName]; ok {
+		return name
+	}
+
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		panic("drivers: Counting of unknown driver " + driverName)
+	}
+
+	name := driverName + "-counting"
+
+	sql.Register(name, countingDriver{driver: db.Driver()})
+
+	counting[driverName] = name
+
+	return name
+}
+
+type countingDriver struct {
+	driver driver.Driver
+}
+
+func (d countingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return countingConn{conn: conn}, nil
+}
+
+// countingConn implements every optional interface of driver.Conn and returns
+// driver.ErrSkip, or behaves like database/sql, if conn does not.
+type countingConn struct {
+	conn driver.Conn
+}
+
+func (c countingConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	prepares.Add(1)
+
+	return countingStmt{stmt: stmt, conn: c.conn, query: query}, nil
+}
+
+func (c countingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	preparer, ok := c.conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	prepares.Add(1)
+
+	return countingStmt{stmt: stmt, conn: c.conn, query: query}, nil
+}
+
+func (c countingConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c countingConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+func (c countingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.conn.Begin()
+}
+
+func (c countingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		executed(c.conn, query, args)
+	}
+
+	return result, err
+}
+
+func (c countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	result, err := queryer.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+
+	executed(c.conn, query, args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return countingRows{rows: result}, nil
+}
+
+func (c countingConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c countingConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c countingConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c countingConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+type countingStmt struct {
+	stmt  driver.Stmt
+	conn  driver.Conn
+	query string
+}
+
+func (s countingStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s countingStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s countingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	executed(s.conn, s.query, ordinal(args))
+
+	return s.stmt.Exec(args)
+}
+
+func (s countingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	executed(s.conn, s.query, ordinal(args))
+
+	result, err := s.stmt.Query(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return countingRows{rows: result}, nil
+}
+
+func (s countingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.stmt.(driver.StmtExecContext)
+	if !ok {
+		values, err := namedValues(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.Exec(values)
+	}
+
+	executed(s.conn, s.query, args)
+
+	return execer.ExecContext(ctx, args)
+}
+
+func (s countingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.stmt.(driver.StmtQueryContext)
+	if !ok {
+		values, err := namedValues(args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.Query(values)
+	}
+
+	executed(s.conn, s.query, args)
+
+	result, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return countingRows{rows: result}, nil
+}
+
+func (s countingStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+// namedValues converts args for a driver.Stmt without context, like
+// database/sql does.
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("drivers: driver does not support named parameters")
+		}
+
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
+
+// ordinal is the reverse of namedValues.
+func ordinal(values []driver.Value) []driver.NamedValue {
+	args := make([]driver.NamedValue, len(values))
+
+	for i, value := range values {
+		args[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+
+	return args
+}
+
+// countingRows implements the optional interfaces of driver.Rows with the
+// defaults of database/sql if rows does not.
+type countingRows struct {
+	rows driver.Rows
+}
+
+func (r countingRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r countingRows) Close() error {
+	return r.rows.Close()
+}
+
+func (r countingRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	if err == nil {
+		rows.Add(1)
+	}
+
+	return err
+}
+
+func (r countingRows) HasNextResultSet() bool {
+	if set, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return set.HasNextResultSet()
+	}
+
+	return false
+}
+
+func (r countingRows) NextResultSet() error {
+	if set, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return set.NextResultSet()
+	}
+
+	return io.EOF
+}
+
+func (r countingRows) ColumnTypeScanType(index int) reflect.Type {
+	if scan, ok := r.rows.(driver.RowsColumnTypeScanType); ok {
+		return scan.ColumnTypeScanType(index)
+	}
+
+	return reflect.TypeFor[any]()
+}
+
+func (r countingRows) ColumnTypeDatabaseTypeName(index int) string {
+	if name, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return name.ColumnTypeDatabaseTypeName(index)
+	}
+
+	return ""
+}
+
+func (r countingRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if null, ok := r.rows.(driver.RowsColumnTypeNullable); ok {
+		return null.ColumnTypeNullable(index)
+	}
+
+	return false, false
+}
+
+func (r countingRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	if l, ok := r.rows.(driver.RowsColumnTypeLength); ok {
+		return l.ColumnTypeLength(index)
+	}
+
+	return 0, false
+}
+
+func (r countingRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	if ps, ok := r.rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return ps.ColumnTypePrecisionScale(index)
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,140 @@
+// Package drivers makes the SQLite driver a dimension of the benchmark. The
+// database/sql based implementations register themselves with Register, which
+// adds a variant name@driver for every pure Go driver next to the default
+// mattn/go-sqlite3 implementation, like sql@modernc.
+package drivers
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	benchflix "github.com/wroge/bench-flix"
+	"modernc.org/sqlite"
+	sqlite3lib "modernc.org/sqlite/lib"
+)
+
+// Open opens a Repository with the database/sql driver driverName.
+type Open func(driverName, dataSourceName string) (benchflix.Repository, error)
+
+// Driver is a SQLite driver for database/sql other than mattn/go-sqlite3.
+type Driver struct {
+	// Name is the suffix of the variants, like modernc in sql@modernc.
+	Name string
+	// DriverName is the name of the driver in database/sql.
+	DriverName string
+	// DSN converts a data source name of mattn/go-sqlite3 for the driver.
+	DSN func(dsn string) string
+	// IsPrimaryKeyViolation reports whether err of the driver is a violation
+	// of a primary key.
+	IsPrimaryKeyViolation func(err error) bool
+}
+
+var drivers = []Driver{
+	{
+		Name:       "modernc",
+		DriverName: "sqlite",
+		DSN: func(dsn string) string {
+			// Write times like mattn/go-sqlite3, so that DATE columns compare
+			// and scan the same.
+			return convert(dsn, "_time_format=sqlite")
+		},
+		IsPrimaryKeyViolation: func(err error) bool {
+			var sqliteErr *sqlite.Error
+
+			return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3lib.SQLITE_CONSTRAINT_PRIMARYKEY
+		},
+	},
+}
+
+// Register registers the implementation name on mattn/go-sqlite3 and a variant
+// name@driver for every other driver. The drivers are wrapped by Counting.
+func Register(name string, open Open) {
+	benchflix.Register(name, func(dsn string) (benchflix.Repository, error) {
+		return open(Counting("sqlite3"), dsn)
+	})
+
+	for _, driver := range drivers {
+		benchflix.Register(name+"@"+driver.Name, func(dsn string) (benchflix.Repository, error) {
+			return open(Counting(driver.DriverName), driver.DSN(dsn))
+		})
+	}
+}
+
+// IsPrimaryKeyViolation reports whether err is a violation of a primary key,
+// whichever driver returned it.
+func IsPrimaryKeyViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	for _, driver := range drivers {
+		if driver.IsPrimaryKeyViolation(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pragmas maps the parameters of mattn/go-sqlite3 to their PRAGMA. The busy
+// timeout comes first, so that it applies to the other statements.
+var pragmas = []struct {
+	param, pragma string
+}{
+	{"_busy_timeout", "busy_timeout"},
+	{"_fk", "foreign_keys"},
+	{"_journal_mode", "journal_mode"},
+	{"_synchronous", "synchronous"},
+}
+
+// Pragmas splits dsn of mattn/go-sqlite3 into the file name, the PRAGMAs its
+// parameters stand for, like foreign_keys(1), and the remaining parameters.
+func Pragmas(dsn string) (string, []string, url.Values) {
+	name, query, _ := strings.Cut(dsn, "?")
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return dsn, nil, nil
+	}
+
+	var list []string
+
+	for _, p := range pragmas {
+		if v := values.Get(p.param); v != "" {
+			list = append(list, p.pragma+"("+v+")")
+
+			values.Del(p.param)
+		}
+	}
+
+	return name, list, values
+}
+
+// convert rewrites the parameters of mattn/go-sqlite3 in dsn to _pragma
+// parameters, which modernc.org/sqlite and ncruces/go-sqlite3 understand, and
+// appends extra. Other parameters, like _txlock, are kept.
+func convert(dsn string, extra ...string) string {
+	name, list, values := Pragmas(dsn)
+
+	params := []string{}
+
+	for _, pragma := range list {
+		params = append(params, "_pragma="+url.QueryEscape(pragma))
+	}
+
+	if rest := values.Encode(); rest != "" {
+		params = append(params, rest)
+	}
+
+	params = append(params, extra...)
+
+	if len(params) == 0 {
+		return name
+	}
+
+	return name + "?" + strings.Join(params, "&")
+}
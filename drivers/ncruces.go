@@ -0,0 +1,34 @@
+//go:build ncruces
+
+package drivers
+
+import (
+	"errors"
+	"strings"
+
+	ncruces "github.com/ncruces/go-sqlite3"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+// ncruces/go-sqlite3 registers itself as sqlite3 like mattn/go-sqlite3, so it
+// has to be renamed when linking, and is only built with the ncruces tag:
+//
+//	go test -tags ncruces -ldflags '-X github.com/ncruces/go-sqlite3/driver.driverName=ncruces'
+func init() {
+	drivers = append(drivers, Driver{
+		Name:       "ncruces",
+		DriverName: "ncruces",
+		DSN: func(dsn string) string {
+			// Parameters are only read from file: URIs.
+			if !strings.HasPrefix(dsn, "file:") {
+				dsn = "file:" + dsn
+			}
+
+			return convert(dsn)
+		},
+		IsPrimaryKeyViolation: func(err error) bool {
+			return errors.Is(err, ncruces.CONSTRAINT_PRIMARYKEY)
+		},
+	})
+}
@@ -0,0 +1,253 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statement is a statement executed through a counting driver, or reported by
+// Executed, with its bound arguments. Plan is only set by Explain.
+type Statement struct {
+	Query string
+	Args  []driver.NamedValue
+	Plan  []string
+}
+
+// String returns the query followed by a comment with the arguments as SQL
+// literals, one statement per block.
+func (s Statement) String() string {
+	if len(s.Args) == 0 {
+		return s.Query
+	}
+
+	args := make([]string, len(s.Args))
+
+	for i, arg := range s.Args {
+		args[i] = literal(arg.Value)
+
+		if arg.Name != "" {
+			args[i] = ":" + arg.Name + " = " + args[i]
+		}
+	}
+
+	return s.Query + "\n-- args: " + strings.Join(args, ", ")
+}
+
+func literal(value driver.Value) string {
+	switch value := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case []byte:
+		return "x'" + hex.EncodeToString(value) + "'"
+	case time.Time:
+		return "'" + value.Format(time.RFC3339Nano) + "'"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+var (
+	tracingMu sync.Mutex
+	tracing   *[]Statement
+	explain   bool
+	// traced avoids the lock in the benchmarks, which run without a Trace.
+	traced atomic.Bool
+)
+
+// Trace records the statements executed through the counting drivers and
+// reported by Executed in the order of execution, until the returned function
+// is called, which returns them. Only one Trace or Explain may run at a time.
+func Trace() func() []Statement {
+	return start(false)
+}
+
+// Explain is like Trace, but also runs EXPLAIN QUERY PLAN for every statement,
+// on the connection of the statement, and sets its Plan.
+func Explain() func() []Statement {
+	return start(true)
+}
+
+func start(plans bool) func() []Statement {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracing != nil {
+		panic("drivers: Trace is already running")
+	}
+
+	statements := []Statement{}
+	tracing = &statements
+	explain = plans
+
+	traced.Store(true)
+
+	return func() []Statement {
+		tracingMu.Lock()
+		defer tracingMu.Unlock()
+
+		tracing = nil
+
+		traced.Store(false)
+
+		return statements
+	}
+}
+
+// record appends a statement of a counting driver to the running Trace.
+func record(conn driver.Conn, query string, args []driver.NamedValue) {
+	if !traced.Load() {
+		return
+	}
+
+	add(query, args, func() []string {
+		return lines(plan(conn, query, args))
+	})
+}
+
+// add appends a statement to the running Trace and sets its Plan for Explain.
+// The arguments are copied, because drivers may reuse their buffers.
+func add(query string, args []driver.NamedValue, queryPlan func() []string) {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	if tracing == nil {
+		return
+	}
+
+	args = slices.Clone(args)
+
+	for i, arg := range args {
+		if value, ok := arg.Value.([]byte); ok {
+			args[i].Value = bytes.Clone(value)
+		}
+	}
+
+	statement := Statement{Query: query, Args: args}
+
+	if explain {
+		statement.Plan = queryPlan()
+	}
+
+	*tracing = append(*tracing, statement)
+}
+
+// PlanRow is a row of EXPLAIN QUERY PLAN.
+type PlanRow struct {
+	ID     int64
+	Parent int64
+	Detail string
+}
+
+// plan runs EXPLAIN QUERY PLAN for query on conn.
+func plan(conn driver.Conn, query string, args []driver.NamedValue) ([]PlanRow, error) {
+	rows, err := queryConn(conn, "EXPLAIN QUERY PLAN "+query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		plan   []PlanRow
+		values = make([]driver.Value, len(rows.Columns()))
+	)
+
+	// The columns are id, parent, notused and detail.
+	for len(values) == 4 && rows.Next(values) == nil {
+		id, _ := values[0].(int64)
+		parent, _ := values[1].(int64)
+
+		detail := values[3]
+		if text, ok := detail.([]byte); ok {
+			detail = string(text)
+		}
+
+		plan = append(plan, PlanRow{ID: id, Parent: parent, Detail: fmt.Sprint(detail)})
+	}
+
+	return plan, nil
+}
+
+// lines returns the rows of a query plan, indented by their depth in the plan
+// like in the sqlite3 shell. If EXPLAIN failed, the error is the plan.
+func lines(plan []PlanRow, err error) []string {
+	if err != nil {
+		return []string{"EXPLAIN QUERY PLAN: " + err.Error()}
+	}
+
+	var (
+		lines  = make([]string, len(plan))
+		depths = map[int64]int{}
+	)
+
+	for i, row := range plan {
+		depth := 0
+		if d, ok := depths[row.Parent]; ok {
+			depth = d + 1
+		}
+
+		depths[row.ID] = depth
+
+		lines[i] = strings.Repeat("  ", depth) + row.Detail
+	}
+
+	return lines
+}
+
+// queryConn queries conn directly, so that the statement is neither counted
+// nor traced.
+func queryConn(conn driver.Conn, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := conn.(driver.QueryerContext); ok {
+		rows, err := queryer.QueryContext(context.Background(), query, args)
+		if err != driver.ErrSkip {
+			return rows, err
+		}
+	}
+
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryer, ok := stmt.(driver.StmtQueryContext); ok {
+		rows, err := queryer.QueryContext(context.Background(), args)
+		if err != nil {
+			return nil, errors.Join(err, stmt.Close())
+		}
+
+		return closingRows{Rows: rows, stmt: stmt}, nil
+	}
+
+	values, err := namedValues(args)
+	if err != nil {
+		return nil, errors.Join(err, stmt.Close())
+	}
+
+	rows, err := stmt.Query(values)
+	if err != nil {
+		return nil, errors.Join(err, stmt.Close())
+	}
+
+	return closingRows{Rows: rows, stmt: stmt}, nil
+}
+
+// closingRows closes the statement of queryConn with its rows.
+type closingRows struct {
+	driver.Rows
+	stmt driver.Stmt
+}
+
+func (r closingRows) Close() error {
+	return errors.Join(r.Rows.Close(), r.stmt.Close())
+}
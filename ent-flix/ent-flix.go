@@ -1,33 +1,50 @@
 package entflix
 
 import (
+	"cmp"
 	"context"
+	stdsql "database/sql"
 	"errors"
 	"math"
+	"slices"
+	"strings"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	_ "github.com/mattn/go-sqlite3"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 	"github.com/wroge/bench-flix/ent-flix/ent"
 	"github.com/wroge/bench-flix/ent-flix/ent/country"
 	"github.com/wroge/bench-flix/ent-flix/ent/genre"
 	"github.com/wroge/bench-flix/ent-flix/ent/movie"
 	"github.com/wroge/bench-flix/ent-flix/ent/person"
+	"github.com/wroge/bench-flix/ent-flix/ent/predicate"
 )
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
-	client, err := ent.Open(driverName, dataSourceName)
+func init() {
+	benchflix.Register("ent", func(dsn string) (benchflix.Repository, error) {
+		return NewRepository(drivers.Counting("sqlite3"), dsn)
+	})
+}
+
+// NewRepository opens the database with the SQLite driver driverName. ent.Open
+// would take the driver name for the dialect.
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	db, err := stdsql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, db)))
+
 	if err = client.Schema.Create(context.Background()); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	return Repository{
 		Client: client,
-	}
+	}, nil
 }
 
 type Repository struct {
@@ -35,9 +52,16 @@ type Repository struct {
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	_, err := r.Client.Movie.Delete().Where(movie.ID(id)).Exec(ctx)
+	deleted, err := r.Client.Movie.Delete().Where(movie.ID(id)).Exec(ctx)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if deleted == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
@@ -54,68 +78,312 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 		}
 	}()
 
-	create := tx.Movie.Create().
+	directors, err := personIDs(ctx, tx, movie.Directors)
+	if err != nil {
+		return err
+	}
+
+	actors, err := personIDs(ctx, tx, movie.Actors)
+	if err != nil {
+		return err
+	}
+
+	countries, err := countryIDs(ctx, tx, movie.Countries)
+	if err != nil {
+		return err
+	}
+
+	genres, err := genreIDs(ctx, tx, movie.Genres)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Movie.Create().
 		SetID(movie.ID).
 		SetTitle(movie.Title).
 		SetAddedAt(movie.AddedAt).
-		SetRating(movie.Rating)
+		SetRating(movie.Rating).
+		AddDirectorIDs(directors...).
+		AddActorIDs(actors...).
+		AddCountryIDs(countries...).
+		AddGenreIDs(genres...).
+		Exec(ctx)
+	if ent.IsConstraintError(err) {
+		return benchflix.ErrAlreadyExists
+	}
 
-	if len(movie.Directors) > 0 {
-		people := make([]int64, len(movie.Directors))
+	return err
+}
 
-		for i, p := range movie.Directors {
-			people[i], err = tx.Person.Create().SetName(p).OnConflict().UpdateName().ID(ctx)
-			if err != nil {
-				return err
-			}
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
 		}
+	}()
 
-		create.AddDirectorIDs(people...)
+	directors, err := personIDs(ctx, tx, movie.Directors)
+	if err != nil {
+		return err
 	}
 
-	if len(movie.Actors) > 0 {
-		people := make([]int64, len(movie.Actors))
+	actors, err := personIDs(ctx, tx, movie.Actors)
+	if err != nil {
+		return err
+	}
 
-		for i, p := range movie.Actors {
-			people[i], err = tx.Person.Create().SetName(p).OnConflict().UpdateName().ID(ctx)
-			if err != nil {
-				return err
-			}
+	countries, err := countryIDs(ctx, tx, movie.Countries)
+	if err != nil {
+		return err
+	}
+
+	genres, err := genreIDs(ctx, tx, movie.Genres)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Movie.UpdateOneID(movie.ID).
+		SetTitle(movie.Title).
+		SetAddedAt(movie.AddedAt).
+		SetRating(movie.Rating).
+		ClearDirectors().
+		AddDirectorIDs(directors...).
+		ClearActors().
+		AddActorIDs(actors...).
+		ClearCountries().
+		AddCountryIDs(countries...).
+		ClearGenres().
+		AddGenreIDs(genres...).
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		return benchflix.ErrNotFound
+	}
+
+	return err
+}
+
+const batchSize = 1000
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
 		}
+	}()
 
-		create.AddActorIDs(people...)
+	var people, countries, genres []string
+
+	for _, movie := range movies {
+		people = append(append(people, movie.Directors...), movie.Actors...)
+		countries = append(countries, movie.Countries...)
+		genres = append(genres, movie.Genres...)
 	}
 
-	if len(movie.Countries) > 0 {
-		countries := make([]int64, len(movie.Countries))
+	peopleIDs, err := upsertPeople(ctx, tx, benchflix.Unique(people))
+	if err != nil {
+		return err
+	}
 
-		for i, p := range movie.Countries {
-			countries[i], err = tx.Country.Create().SetName(p).OnConflict().UpdateName().ID(ctx)
-			if err != nil {
-				return err
-			}
+	countriesIDs, err := upsertCountries(ctx, tx, benchflix.Unique(countries))
+	if err != nil {
+		return err
+	}
+
+	genresIDs, err := upsertGenres(ctx, tx, benchflix.Unique(genres))
+	if err != nil {
+		return err
+	}
+
+	ids := func(names []string, lookup map[string]int64) []int64 {
+		result := make([]int64, len(names))
+
+		for i, name := range names {
+			result[i] = lookup[name]
 		}
 
-		create.AddCountryIDs(countries...)
+		return result
 	}
 
-	if len(movie.Genres) > 0 {
-		genres := make([]int64, len(movie.Genres))
+	for batch := range slices.Chunk(movies, batchSize) {
+		builders := make([]*ent.MovieCreate, len(batch))
+
+		for i, movie := range batch {
+			builders[i] = tx.Movie.Create().
+				SetID(movie.ID).
+				SetTitle(movie.Title).
+				SetAddedAt(movie.AddedAt).
+				SetRating(movie.Rating).
+				AddDirectorIDs(ids(movie.Directors, peopleIDs)...).
+				AddActorIDs(ids(movie.Actors, peopleIDs)...).
+				AddCountryIDs(ids(movie.Countries, countriesIDs)...).
+				AddGenreIDs(ids(movie.Genres, genresIDs)...)
+		}
 
-		for i, p := range movie.Genres {
-			genres[i], err = tx.Genre.Create().SetName(p).OnConflict().UpdateName().ID(ctx)
-			if err != nil {
-				return err
+		if err = tx.Movie.CreateBulk(builders...).Exec(ctx); err != nil {
+			if ent.IsConstraintError(err) {
+				return benchflix.ErrAlreadyExists
 			}
+
+			return err
 		}
+	}
+
+	return nil
+}
+
+func upsertPeople(ctx context.Context, tx *ent.Tx, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
 
-		create.AddGenreIDs(genres...)
+	for batch := range slices.Chunk(names, batchSize) {
+		builders := make([]*ent.PersonCreate, len(batch))
+
+		for i, name := range batch {
+			builders[i] = tx.Person.Create().SetName(name)
+		}
+
+		if err := tx.Person.CreateBulk(builders...).OnConflict().UpdateName().Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		result, err := tx.Person.Query().Where(person.NameIn(batch...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, each := range result {
+			ids[each.Name] = each.ID
+		}
 	}
 
-	return create.Exec(ctx)
+	return ids, nil
 }
 
+func upsertCountries(ctx context.Context, tx *ent.Tx, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+
+	for batch := range slices.Chunk(names, batchSize) {
+		builders := make([]*ent.CountryCreate, len(batch))
+
+		for i, name := range batch {
+			builders[i] = tx.Country.Create().SetName(name)
+		}
+
+		if err := tx.Country.CreateBulk(builders...).OnConflict().UpdateName().Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		result, err := tx.Country.Query().Where(country.NameIn(batch...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, each := range result {
+			ids[each.Name] = each.ID
+		}
+	}
+
+	return ids, nil
+}
+
+func upsertGenres(ctx context.Context, tx *ent.Tx, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+
+	for batch := range slices.Chunk(names, batchSize) {
+		builders := make([]*ent.GenreCreate, len(batch))
+
+		for i, name := range batch {
+			builders[i] = tx.Genre.Create().SetName(name)
+		}
+
+		if err := tx.Genre.CreateBulk(builders...).OnConflict().UpdateName().Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		result, err := tx.Genre.Query().Where(genre.NameIn(batch...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, each := range result {
+			ids[each.Name] = each.ID
+		}
+	}
+
+	return ids, nil
+}
+
+func personIDs(ctx context.Context, tx *ent.Tx, names []string) ([]int64, error) {
+	ids := make([]int64, len(names))
+
+	for i, name := range names {
+		id, err := tx.Person.Create().SetName(name).OnConflict().UpdateName().ID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+func countryIDs(ctx context.Context, tx *ent.Tx, names []string) ([]int64, error) {
+	ids := make([]int64, len(names))
+
+	for i, name := range names {
+		id, err := tx.Country.Create().SetName(name).OnConflict().UpdateName().ID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+func genreIDs(ctx context.Context, tx *ent.Tx, names []string) ([]int64, error) {
+	ids := make([]int64, len(names))
+
+	for i, name := range names {
+		id, err := tx.Genre.Create().SetName(name).OnConflict().UpdateName().ID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// Query loads all movies at once. ent has no API to scan and decode the rows of
+// a query one by one, so Repository does not implement benchflix.Streamer.
 func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	direction := sql.OrderAsc()
+	if query.Sort.Descending {
+		direction = sql.OrderDesc()
+	}
+
 	q := r.Client.Movie.Query().
 		WithDirectors(
 			func(ptq *ent.PersonQuery) {
@@ -134,55 +402,132 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 				ptq.Order(genre.ByName(sql.OrderAsc()))
 			},
 		).
-		Order(movie.ByTitle(sql.OrderAsc()))
+		Order(sql.OrderByField(column, direction).ToFunc(), movie.ByID(direction))
 
 	if query.Limit > 0 && query.Limit < math.MaxInt {
 		q = q.Limit(int(query.Limit))
 	}
 
+	if query.Offset > 0 && query.Offset < math.MaxInt {
+		q = q.Offset(int(query.Offset))
+	}
+
+	q.Where(filter(query)...)
+
+	if query.After != nil {
+		compare := sql.CompositeGT
+		if query.Sort.Descending {
+			compare = sql.CompositeLT
+		}
+
+		q.Where(func(s *sql.Selector) {
+			s.Where(compare([]string{s.C(column), s.C(movie.FieldID)}, query.After.Value(query.Sort.Field), query.After.ID))
+		})
+	}
+
+	result, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make([]benchflix.Movie, len(result))
+
+	for i, each := range result {
+		movies[i] = ConvertMovie(each)
+	}
+
+	return movies, nil
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	predicates := filter(query)
+
+	count, err := r.Client.Movie.Query().Where(predicates...).Count(ctx)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	facets := benchflix.Facets{
+		Count: int64(count),
+	}
+
+	err = r.Client.Movie.Query().
+		Where(predicates...).
+		QueryGenres().
+		GroupBy(genre.FieldName).
+		Aggregate(ent.Count()).
+		Scan(ctx, &facets.Genres)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = r.Client.Movie.Query().
+		Where(predicates...).
+		QueryCountries().
+		GroupBy(country.FieldName).
+		Aggregate(ent.Count()).
+		Scan(ctx, &facets.Countries)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	slices.SortFunc(facets.Genres, compareFacets)
+	slices.SortFunc(facets.Countries, compareFacets)
+
+	return facets, nil
+}
+
+func compareFacets(a, b benchflix.FacetCount) int {
+	if a.Count != b.Count {
+		return cmp.Compare(b.Count, a.Count)
+	}
+
+	return strings.Compare(a.Name, b.Name)
+}
+
+func filter(query benchflix.Query) []predicate.Movie {
+	var predicates []predicate.Movie
+
 	if query.Search != "" {
-		q.Where(movie.Or(
-			movie.HasDirectorsWith(person.NameContains(query.Search)),
-			movie.HasActorsWith(person.NameContains(query.Search)),
+		predicates = append(predicates, movie.Or(
+			movie.HasDirectorsWith(nameContains(query.Search)),
+			movie.HasActorsWith(nameContains(query.Search)),
 		))
 	}
 
 	if query.Genre != "" {
-		q.Where(movie.HasGenresWith(genre.Name(query.Genre)))
+		predicates = append(predicates, movie.HasGenresWith(genre.Name(query.Genre)))
 	}
 
 	if query.Country != "" {
-		q.Where(movie.HasCountriesWith(country.Name(query.Country)))
+		predicates = append(predicates, movie.HasCountriesWith(country.Name(query.Country)))
 	}
 
 	if !query.AddedAfter.IsZero() {
-		q.Where(movie.AddedAtGT(query.AddedAfter))
+		predicates = append(predicates, movie.AddedAtGT(query.AddedAfter))
 	}
 
 	if !query.AddedBefore.IsZero() {
-		q.Where(movie.AddedAtLT(query.AddedBefore))
+		predicates = append(predicates, movie.AddedAtLT(query.AddedBefore))
 	}
 
 	if query.MinRating > 0 {
-		q.Where(movie.RatingGTE(query.MinRating))
+		predicates = append(predicates, movie.RatingGTE(query.MinRating))
 	}
 
 	if query.MaxRating > 0 {
-		q.Where(movie.RatingLTE(query.MaxRating))
+		predicates = append(predicates, movie.RatingLTE(query.MaxRating))
 	}
 
-	result, err := q.All(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	movies := make([]benchflix.Movie, len(result))
+	return predicates
+}
 
-	for i, each := range result {
-		movies[i] = ConvertMovie(each)
+// nameContains matches people whose name contains substr. person.NameContains
+// uses LIKE, which ignores the case in SQLite.
+func nameContains(substr string) predicate.Person {
+	return func(s *sql.Selector) {
+		s.Where(sql.ExprP("INSTR("+s.C(person.FieldName)+", ?) > 0", substr))
 	}
-
-	return movies, nil
 }
 
 func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
@@ -207,6 +552,10 @@ func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error)
 		Order(movie.ByTitle(sql.OrderAsc())).
 		Only(ctx)
 	if err != nil {
+		if ent.IsNotFound(err) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
@@ -0,0 +1,443 @@
+package goquflix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/dialect/sqlite3"
+	"github.com/doug-martin/goqu/v9/exp"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+)
+
+// dialect is the sqlite3 dialect of goqu with RETURNING, which SQLite supports
+// since 3.35.
+const dialect = "sqlite3-returning"
+
+func init() {
+	options := sqlite3.DialectOptions()
+	options.SupportsReturn = true
+
+	goqu.RegisterDialect(dialect, options)
+
+	drivers.Register("goqu", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	sqldb, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	db := goqu.New(dialect, sqldb)
+
+	_, err = db.Exec(
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: db,
+	}, nil
+}
+
+// Repository builds every statement with Prepared(true), so that goqu uses
+// placeholders instead of interpolating the arguments, which formats times
+// differently than the driver. The statements are not prepared on the
+// connection; sql-prepared measures that.
+type Repository struct {
+	DB *goqu.Database
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB.Delete("movies").
+		Where(goqu.C("id").Eq(id)).
+		Prepared(true).
+		Executor().
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = tx.Insert("movies").
+		Cols("id", "title", "added_at", "rating").
+		Vals(goqu.Vals{movie.ID, movie.Title, movie.AddedAt, movie.Rating}).
+		Prepared(true).
+		Executor().
+		ExecContext(ctx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := tx.Update("movies").
+		Set(goqu.Record{"title": movie.Title, "added_at": movie.AddedAt, "rating": movie.Rating}).
+		Where(goqu.C("id").Eq(movie.ID)).
+		Prepared(true).
+		Executor().
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+		_, err = tx.Delete(table).
+			Where(goqu.C("movie_id").Eq(movie.ID)).
+			Prepared(true).
+			Executor().
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func insertRelations(ctx context.Context, tx *goqu.TxDatabase, movie benchflix.Movie) error {
+	if err := insertNames(ctx, tx, "people", "movie_directors", "person_id", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "people", "movie_actors", "person_id", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "countries", "movie_countries", "country_id", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(ctx, tx, "genres", "movie_genres", "genre_id", movie.ID, movie.Genres)
+}
+
+// insertNames upserts names into table and links their IDs to the movie.
+func insertNames(ctx context.Context, tx *goqu.TxDatabase, table, links, column string, movieID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	values := make([][]any, len(names))
+
+	for i, name := range names {
+		values[i] = []any{name}
+	}
+
+	var ids []int64
+
+	err := tx.Insert(table).
+		Cols("name").
+		Vals(values...).
+		OnConflict(goqu.DoUpdate("name", goqu.Record{"name": goqu.I("excluded.name")})).
+		Returning("id").
+		Prepared(true).
+		Executor().
+		ScanValsContext(ctx, &ids)
+	if err != nil {
+		return err
+	}
+
+	values = values[:0]
+
+	for _, id := range ids {
+		values = append(values, []any{movieID, id})
+	}
+
+	_, err = tx.Insert(links).
+		Cols("movie_id", column).
+		Vals(values...).
+		Prepared(true).
+		Executor().
+		ExecContext(ctx)
+
+	return err
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) []exp.Expression {
+	var conditions []exp.Expression
+
+	if query.Search != "" {
+		conditions = append(conditions, goqu.Or(
+			exists("movie_directors", "people", "person_id", goqu.L("INSTR(people.name, ?) > 0", query.Search)),
+			exists("movie_actors", "people", "person_id", goqu.L("INSTR(people.name, ?) > 0", query.Search)),
+		))
+	}
+
+	if query.Genre != "" {
+		conditions = append(conditions, exists("movie_genres", "genres", "genre_id", goqu.I("genres.name").Eq(query.Genre)))
+	}
+
+	if query.Country != "" {
+		conditions = append(conditions, exists("movie_countries", "countries", "country_id", goqu.I("countries.name").Eq(query.Country)))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		conditions = append(conditions, goqu.I("movies.added_at").Lt(query.AddedBefore))
+	}
+
+	if !query.AddedAfter.IsZero() {
+		conditions = append(conditions, goqu.I("movies.added_at").Gt(query.AddedAfter))
+	}
+
+	if query.MinRating > 0 {
+		conditions = append(conditions, goqu.I("movies.rating").Gte(query.MinRating))
+	}
+
+	if query.MaxRating > 0 {
+		conditions = append(conditions, goqu.I("movies.rating").Lte(query.MaxRating))
+	}
+
+	return conditions
+}
+
+// exists matches movies linked to a row of table that satisfies condition.
+func exists(links, table, column string, condition exp.Expression) exp.Expression {
+	return goqu.L("EXISTS ?", goqu.From(links).
+		Select(goqu.L("1")).
+		Join(goqu.T(table), goqu.On(goqu.I(table+".id").Eq(goqu.I(links+"."+column)))).
+		Where(goqu.I(links+".movie_id").Eq(goqu.I("movies.id")), condition))
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, table, column, alias string) exp.Expression {
+	return goqu.From(links).
+		Select(goqu.L("json_group_array(? ORDER BY ?)", goqu.I(table+".name"), goqu.I(table+".name"))).
+		Join(goqu.T(table), goqu.On(goqu.I(table+".id").Eq(goqu.I(links+"."+column)))).
+		Where(goqu.I(links + ".movie_id").Eq(goqu.I("movies.id"))).
+		As(alias)
+}
+
+var columns = []any{
+	goqu.I("movies.id"),
+	goqu.I("movies.title"),
+	goqu.I("movies.added_at"),
+	goqu.I("movies.rating"),
+	aggregate("movie_directors", "people", "person_id", "directors"),
+	aggregate("movie_actors", "people", "person_id", "actors"),
+	aggregate("movie_countries", "countries", "country_id", "countries"),
+	aggregate("movie_genres", "genres", "genre_id", "genres"),
+}
+
+type Movie struct {
+	ID        int64     `db:"id"`
+	Title     string    `db:"title"`
+	AddedAt   time.Time `db:"added_at"`
+	Rating    float64   `db:"rating"`
+	Directors []byte    `db:"directors"`
+	Actors    []byte    `db:"actors"`
+	Countries []byte    `db:"countries"`
+	Genres    []byte    `db:"genres"`
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := filter(query)
+
+	if query.After != nil {
+		keyset := goqu.L("(?, ?)", goqu.I("movies."+column), goqu.I("movies.id"))
+		cursor := goqu.L("(?, ?)", query.After.Value(query.Sort.Field), query.After.ID)
+
+		if query.Sort.Descending {
+			conditions = append(conditions, goqu.L("? < ?", keyset, cursor))
+		} else {
+			conditions = append(conditions, goqu.L("? > ?", keyset, cursor))
+		}
+	}
+
+	stmt := r.DB.From("movies").
+		Select(columns...).
+		Where(conditions...).
+		Prepared(true)
+
+	if query.Sort.Descending {
+		stmt = stmt.Order(goqu.I("movies."+column).Desc(), goqu.I("movies.id").Desc())
+	} else {
+		stmt = stmt.Order(goqu.I("movies."+column).Asc(), goqu.I("movies.id").Asc())
+	}
+
+	if query.Limit > 0 {
+		stmt = stmt.Limit(uint(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT.
+			stmt = stmt.Limit(math.MaxInt64)
+		}
+
+		stmt = stmt.Offset(uint(query.Offset))
+	}
+
+	var movies []Movie
+
+	if err = stmt.ScanStructsContext(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i], err = ConvertMovie(movie)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	found, err := r.DB.From("movies").
+		Select(columns...).
+		Where(goqu.I("movies.id").Eq(id)).
+		Prepared(true).
+		ScanStructContext(ctx, &movie)
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if !found {
+		return benchflix.Movie{}, benchflix.ErrNotFound
+	}
+
+	return ConvertMovie(movie)
+}
+
+// ConvertMovie decodes the names aggregated with json_group_array.
+func ConvertMovie(movie Movie) (benchflix.Movie, error) {
+	result := benchflix.Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+
+	if err := json.Unmarshal(movie.Directors, &result.Directors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Actors, &result.Actors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Countries, &result.Countries); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Genres, &result.Genres); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return result, nil
+}
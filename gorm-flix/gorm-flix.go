@@ -2,10 +2,13 @@ package gormflix
 
 import (
 	"context"
+	"errors"
+	"iter"
 	"math"
 	"time"
 
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -57,23 +60,28 @@ type MovieGenre struct {
 	GenreID int64 `gorm:"primaryKey;not null"`
 }
 
-func NewRepository(dsn string) benchflix.Repository {
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+func init() {
+	benchflix.Register("gorm", NewRepository)
+}
+
+func NewRepository(dsn string) (benchflix.Repository, error) {
+	db, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: drivers.Counting("sqlite3"), DSN: dsn}), &gorm.Config{
 		SkipDefaultTransaction: true,
+		TranslateError:         true,
 	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if err := db.AutoMigrate(
 		&Movie{}, &Person{}, &Country{}, &Genre{},
 		&MovieDirector{}, &MovieActor{}, &MovieCountry{}, &MovieGenre{}); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	return Repository{
 		DB: db,
-	}
+	}, nil
 }
 
 type Repository struct {
@@ -81,110 +89,275 @@ type Repository struct {
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	return r.DB.Delete(Movie{ID: id}).Error
+	result := r.DB.WithContext(ctx).Delete(Movie{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) error {
 	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		create := Movie{
-			ID:      movie.ID,
-			Title:   movie.Title,
-			AddedAt: movie.AddedAt,
-			Rating:  movie.Rating,
+		create, err := upsertRelations(tx, movie)
+		if err != nil {
+			return err
 		}
 
-		if len(movie.Directors) > 0 {
-			create.Directors = make([]*Person, len(movie.Directors))
+		err = tx.Create(&create).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return benchflix.ErrAlreadyExists
+		}
 
-			for i, name := range movie.Directors {
-				create.Directors[i] = &Person{
-					Name: name,
+		return err
+	})
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Movie{ID: movie.ID}).Updates(map[string]any{
+			"title":    movie.Title,
+			"added_at": movie.AddedAt,
+			"rating":   movie.Rating,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return benchflix.ErrNotFound
+		}
+
+		update, err := upsertRelations(tx, movie)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Model(&update).Association("Directors").Replace(update.Directors); err != nil {
+			return err
+		}
+
+		if err = tx.Model(&update).Association("Actors").Replace(update.Actors); err != nil {
+			return err
+		}
+
+		if err = tx.Model(&update).Association("Countries").Replace(update.Countries); err != nil {
+			return err
+		}
+
+		return tx.Model(&update).Association("Genres").Replace(update.Genres)
+	})
+}
+
+const batchSize = 1000
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var (
+			list         = make([]Movie, len(movies))
+			people       = map[string]*Person{}
+			countries    = map[string]*Country{}
+			genres       = map[string]*Genre{}
+			newPeople    []*Person
+			newCountries []*Country
+			newGenres    []*Genre
+		)
+
+		person := func(name string) *Person {
+			if p, ok := people[name]; ok {
+				return p
+			}
+
+			p := &Person{Name: name}
+			people[name] = p
+			newPeople = append(newPeople, p)
+
+			return p
+		}
+
+		for i, movie := range movies {
+			list[i] = Movie{
+				ID:        movie.ID,
+				Title:     movie.Title,
+				AddedAt:   movie.AddedAt,
+				Rating:    movie.Rating,
+				Directors: make([]*Person, len(movie.Directors)),
+				Actors:    make([]*Person, len(movie.Actors)),
+				Countries: make([]*Country, len(movie.Countries)),
+				Genres:    make([]*Genre, len(movie.Genres)),
+			}
+
+			for j, name := range movie.Directors {
+				list[i].Directors[j] = person(name)
+			}
+
+			for j, name := range movie.Actors {
+				list[i].Actors[j] = person(name)
+			}
+
+			for j, name := range movie.Countries {
+				c, ok := countries[name]
+				if !ok {
+					c = &Country{Name: name}
+					countries[name] = c
+					newCountries = append(newCountries, c)
+				}
+
+				list[i].Countries[j] = c
+			}
+
+			for j, name := range movie.Genres {
+				g, ok := genres[name]
+				if !ok {
+					g = &Genre{Name: name}
+					genres[name] = g
+					newGenres = append(newGenres, g)
 				}
+
+				list[i].Genres[j] = g
 			}
+		}
 
-			if err := tx.Clauses(clause.OnConflict{
-				DoUpdates: clause.Set{
-					clause.Assignment{
-						Column: clause.Column{Name: "name"},
-						Value:  gorm.Expr("EXCLUDED.name"),
-					},
+		onConflict := clause.OnConflict{
+			DoUpdates: clause.Set{
+				clause.Assignment{
+					Column: clause.Column{Name: "name"},
+					Value:  gorm.Expr("EXCLUDED.name"),
 				},
-			}).Create(create.Directors).Error; err != nil {
+			},
+		}
+
+		if len(newPeople) > 0 {
+			if err := tx.Clauses(onConflict).CreateInBatches(newPeople, batchSize).Error; err != nil {
 				return err
 			}
 		}
 
-		if len(movie.Actors) > 0 {
-			create.Actors = make([]*Person, len(movie.Actors))
-
-			for i, name := range movie.Actors {
-				create.Actors[i] = &Person{
-					Name: name,
-				}
+		if len(newCountries) > 0 {
+			if err := tx.Clauses(onConflict).CreateInBatches(newCountries, batchSize).Error; err != nil {
+				return err
 			}
+		}
 
-			if err := tx.Clauses(clause.OnConflict{
-				DoUpdates: clause.Set{
-					clause.Assignment{
-						Column: clause.Column{Name: "name"},
-						Value:  gorm.Expr("EXCLUDED.name"),
-					},
-				},
-			}).Create(create.Actors).Error; err != nil {
+		if len(newGenres) > 0 {
+			if err := tx.Clauses(onConflict).CreateInBatches(newGenres, batchSize).Error; err != nil {
 				return err
 			}
 		}
 
-		if len(movie.Countries) > 0 {
-			create.Countries = make([]*Country, len(movie.Countries))
+		err := tx.Omit("Directors.*", "Actors.*", "Countries.*", "Genres.*").
+			CreateInBatches(&list, batchSize).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	})
+}
 
-			for i, name := range movie.Countries {
-				create.Countries[i] = &Country{
-					Name: name,
-				}
+func upsertRelations(tx *gorm.DB, movie benchflix.Movie) (Movie, error) {
+	create := Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+
+	if len(movie.Directors) > 0 {
+		create.Directors = make([]*Person, len(movie.Directors))
+
+		for i, name := range movie.Directors {
+			create.Directors[i] = &Person{
+				Name: name,
 			}
+		}
 
-			if err := tx.Clauses(clause.OnConflict{
-				DoUpdates: clause.Set{
-					clause.Assignment{
-						Column: clause.Column{Name: "name"},
-						Value:  gorm.Expr("EXCLUDED.name"),
-					},
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Set{
+				clause.Assignment{
+					Column: clause.Column{Name: "name"},
+					Value:  gorm.Expr("EXCLUDED.name"),
 				},
-			}).Create(create.Countries).Error; err != nil {
-				return err
+			},
+		}).Create(create.Directors).Error; err != nil {
+			return Movie{}, err
+		}
+	}
+
+	if len(movie.Actors) > 0 {
+		create.Actors = make([]*Person, len(movie.Actors))
+
+		for i, name := range movie.Actors {
+			create.Actors[i] = &Person{
+				Name: name,
 			}
 		}
 
-		if len(movie.Genres) > 0 {
-			create.Genres = make([]*Genre, len(movie.Genres))
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Set{
+				clause.Assignment{
+					Column: clause.Column{Name: "name"},
+					Value:  gorm.Expr("EXCLUDED.name"),
+				},
+			},
+		}).Create(create.Actors).Error; err != nil {
+			return Movie{}, err
+		}
+	}
+
+	if len(movie.Countries) > 0 {
+		create.Countries = make([]*Country, len(movie.Countries))
 
-			for i, name := range movie.Genres {
-				create.Genres[i] = &Genre{
-					Name: name,
-				}
+		for i, name := range movie.Countries {
+			create.Countries[i] = &Country{
+				Name: name,
 			}
+		}
 
-			if err := tx.Clauses(clause.OnConflict{
-				DoUpdates: clause.Set{
-					clause.Assignment{
-						Column: clause.Column{Name: "name"},
-						Value:  gorm.Expr("EXCLUDED.name"),
-					},
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Set{
+				clause.Assignment{
+					Column: clause.Column{Name: "name"},
+					Value:  gorm.Expr("EXCLUDED.name"),
 				},
-			}).Create(create.Genres).Error; err != nil {
-				return err
+			},
+		}).Create(create.Countries).Error; err != nil {
+			return Movie{}, err
+		}
+	}
+
+	if len(movie.Genres) > 0 {
+		create.Genres = make([]*Genre, len(movie.Genres))
+
+		for i, name := range movie.Genres {
+			create.Genres[i] = &Genre{
+				Name: name,
 			}
 		}
 
-		return tx.Create(&create).Error
-	})
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Set{
+				clause.Assignment{
+					Column: clause.Column{Name: "name"},
+					Value:  gorm.Expr("EXCLUDED.name"),
+				},
+			},
+		}).Create(create.Genres).Error; err != nil {
+			return Movie{}, err
+		}
+	}
+
+	return create, nil
 }
 
 func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
 	var list []Movie
 
-	db := r.DB.WithContext(ctx).
+	db, err := paginate(r.DB.WithContext(ctx).
 		Preload("Directors", func(db *gorm.DB) *gorm.DB {
 			return db.Order("name ASC")
 		}).
@@ -197,18 +370,190 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		Preload("Genres", func(db *gorm.DB) *gorm.DB {
 			return db.Order("name ASC")
 		}).
-		Distinct("movies.*").
-		Order("movies.title ASC")
+		Distinct("movies.*"), query)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make([]benchflix.Movie, len(list))
+
+	for i, one := range list {
+		movies[i] = ConvertMovie(one)
+	}
+
+	return movies, nil
+}
+
+// MovieRow is a movie with its relations aggregated into JSON array
+// columns. Preload does not work with Rows, so Stream selects it instead.
+type MovieRow struct {
+	ID                                   int64
+	Title                                string
+	AddedAt                              time.Time
+	Rating                               float64
+	Directors, Actors, Countries, Genres []string `gorm:"serializer:json"`
+}
+
+func (r Repository) Stream(ctx context.Context, query benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		db, err := paginate(r.DB.WithContext(ctx).
+			Model(&Movie{}).
+			Distinct(`movies.id,
+				movies.title,
+				movies.added_at,
+				movies.rating,
+				(
+					SELECT json_group_array(people.name ORDER BY people.name)
+					FROM movie_directors
+					JOIN people ON people.id = movie_directors.person_id
+					WHERE movie_directors.movie_id = movies.id
+				) AS directors,
+				(
+					SELECT json_group_array(people.name ORDER BY people.name)
+					FROM movie_actors
+					JOIN people ON people.id = movie_actors.person_id
+					WHERE movie_actors.movie_id = movies.id
+				) AS actors,
+				(
+					SELECT json_group_array(countries.name ORDER BY countries.name)
+					FROM movie_countries
+					JOIN countries ON countries.id = movie_countries.country_id
+					WHERE movie_countries.movie_id = movies.id
+				) AS countries,
+				(
+					SELECT json_group_array(genres.name ORDER BY genres.name)
+					FROM movie_genres
+					JOIN genres ON genres.id = movie_genres.genre_id
+					WHERE movie_genres.movie_id = movies.id
+				) AS genres`), query)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		rows, err := db.Rows()
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var row MovieRow
+
+			if err = db.ScanRows(rows, &row); err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			movie := benchflix.Movie{
+				ID:        row.ID,
+				Title:     row.Title,
+				AddedAt:   row.AddedAt,
+				Rating:    row.Rating,
+				Directors: row.Directors,
+				Actors:    row.Actors,
+				Countries: row.Countries,
+				Genres:    row.Genres,
+			}
+
+			if !yield(movie, nil) {
+				return
+			}
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			yield(benchflix.Movie{}, err)
+		}
+	}
+}
+
+// paginate filters, sorts and limits a statement on the movies table.
+func paginate(db *gorm.DB, query benchflix.Query) (*gorm.DB, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	db = db.Order("movies." + column + " " + query.Sort.Direction()).
+		Order("movies.id " + query.Sort.Direction())
 
 	if query.Limit > 0 && query.Limit < math.MaxInt {
 		db = db.Limit(int(query.Limit))
 	}
 
+	if query.Offset > 0 && query.Offset < math.MaxInt {
+		db = db.Offset(int(query.Offset))
+	}
+
+	db = filter(db, query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		db = db.Where("(movies."+column+", movies.id) "+operator+" (?, ?)",
+			query.After.Value(query.Sort.Field), query.After.ID)
+	}
+
+	return db, nil
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	var facets benchflix.Facets
+
+	db := r.DB.WithContext(ctx)
+	ids := filter(db.Model(&Movie{}).Select("movies.id"), query)
+
+	err := db.Model(&Movie{}).Where("id IN (?)", ids).Count(&facets.Count).Error
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = db.Table("movie_genres").
+		Select("genres.name AS name, COUNT(*) AS count").
+		Joins("JOIN genres ON genres.id = movie_genres.genre_id").
+		Where("movie_genres.movie_id IN (?)", ids).
+		Group("genres.name").
+		Order("count DESC, genres.name ASC").
+		Scan(&facets.Genres).Error
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = db.Table("movie_countries").
+		Select("countries.name AS name, COUNT(*) AS count").
+		Joins("JOIN countries ON countries.id = movie_countries.country_id").
+		Where("movie_countries.movie_id IN (?)", ids).
+		Group("countries.name").
+		Order("count DESC, countries.name ASC").
+		Scan(&facets.Countries).Error
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	return facets, nil
+}
+
+// filter adds the conditions of query to a statement on the movies table.
+func filter(db *gorm.DB, query benchflix.Query) *gorm.DB {
 	if query.Search != "" {
-		db = db.Joins("JOIN movie_directors md ON md.movie_id = movies.id").
-			Joins("JOIN people d ON d.id = md.person_id").
-			Joins("JOIN movie_actors ma ON ma.movie_id = movies.id").
-			Joins("JOIN people a ON a.id = ma.person_id").
+		// Left joins, so that movies without directors or actors can match too.
+		db = db.Joins("LEFT JOIN movie_directors md ON md.movie_id = movies.id").
+			Joins("LEFT JOIN people d ON d.id = md.person_id").
+			Joins("LEFT JOIN movie_actors ma ON ma.movie_id = movies.id").
+			Joins("LEFT JOIN people a ON a.id = ma.person_id").
 			Where("INSTR(d.name, ?) > 0 OR INSTR(a.name, ?) > 0", query.Search, query.Search)
 	}
 
@@ -240,18 +585,7 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		db = db.Where("rating <= ?", query.MaxRating)
 	}
 
-	err := db.Find(&list).Error
-	if err != nil {
-		return nil, err
-	}
-
-	movies := make([]benchflix.Movie, len(list))
-
-	for i, one := range list {
-		movies[i] = ConvertMovie(one)
-	}
-
-	return movies, nil
+	return db
 }
 
 func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
@@ -273,6 +607,10 @@ func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error)
 		Order("movies.title ASC").
 		First(&one, "id = ?", id).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
@@ -0,0 +1,54 @@
+//go:build ignore
+
+// generate creates the schema of sqlc-flix in an in-memory database and
+// generates the jet table and model types from it into ./internal. INTEGER
+// columns are mapped to int64 instead of int32, because the IDs of the dataset
+// do not fit.
+package main
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/go-jet/jet/v2/generator/metadata"
+	"github.com/go-jet/jet/v2/generator/sqlite"
+	"github.com/go-jet/jet/v2/generator/template"
+	jet "github.com/go-jet/jet/v2/sqlite"
+	_ "github.com/mattn/go-sqlite3"
+	sqlcflix "github.com/wroge/bench-flix/sqlc-flix"
+)
+
+func main() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if _, err = db.Exec(sqlcflix.Schema); err != nil {
+		log.Fatal(err)
+	}
+
+	tmpl := template.Default(jet.Dialect).
+		UseSchema(func(schema metadata.Schema) template.Schema {
+			return template.DefaultSchema(schema).
+				UseModel(template.DefaultModel().
+					UseTable(func(table metadata.Table) template.TableModel {
+						return template.DefaultTableModel(table).
+							UseField(func(column metadata.Column) template.TableModelField {
+								field := template.DefaultTableModelField(column)
+
+								if column.DataType.Name == "INTEGER" {
+									field = field.UseType(template.NewType(int64(0)))
+								}
+
+								return field
+							})
+					}))
+		})
+
+	if err = sqlite.GenerateDB(db, "internal", tmpl); err != nil {
+		log.Fatal(err)
+	}
+}
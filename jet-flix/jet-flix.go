@@ -0,0 +1,518 @@
+package jetflix
+
+//go:generate go run generate.go
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/go-jet/jet/v2/sqlite"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+	"github.com/wroge/bench-flix/jet-flix/internal/model"
+	"github.com/wroge/bench-flix/jet-flix/internal/table"
+	sqlcflix "github.com/wroge/bench-flix/sqlc-flix"
+)
+
+func init() {
+	drivers.Register("jet", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(sqlcflix.Schema); err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: db,
+	}, nil
+}
+
+type Repository struct {
+	DB *sql.DB
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := table.Movies.DELETE().
+		WHERE(table.Movies.ID.EQ(sqlite.Int(id))).
+		ExecContext(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = table.Movies.INSERT(table.Movies.AllColumns).
+		MODEL(model.Movies{
+			ID:      movie.ID,
+			Title:   movie.Title,
+			AddedAt: movie.AddedAt,
+			Rating:  movie.Rating,
+		}).
+		ExecContext(ctx, tx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := table.Movies.UPDATE(table.Movies.MutableColumns).
+		MODEL(model.Movies{
+			Title:   movie.Title,
+			AddedAt: movie.AddedAt,
+			Rating:  movie.Rating,
+		}).
+		WHERE(table.Movies.ID.EQ(sqlite.Int(movie.ID))).
+		ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, links := range []struct {
+		table   sqlite.Table
+		movieID sqlite.ColumnInteger
+	}{
+		{table.MovieDirectors, table.MovieDirectors.MovieID},
+		{table.MovieActors, table.MovieActors.MovieID},
+		{table.MovieCountries, table.MovieCountries.MovieID},
+		{table.MovieGenres, table.MovieGenres.MovieID},
+	} {
+		_, err = links.table.DELETE().
+			WHERE(links.movieID.EQ(sqlite.Int(movie.ID))).
+			ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func insertRelations(ctx context.Context, tx *sql.Tx, movie benchflix.Movie) error {
+	directorIDs, err := insertPeople(ctx, tx, movie.Directors)
+	if err != nil {
+		return err
+	}
+
+	if len(directorIDs) > 0 {
+		directors := make([]model.MovieDirectors, len(directorIDs))
+
+		for i, id := range directorIDs {
+			directors[i] = model.MovieDirectors{MovieID: movie.ID, PersonID: id}
+		}
+
+		_, err = table.MovieDirectors.INSERT(table.MovieDirectors.AllColumns).MODELS(directors).ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	actorIDs, err := insertPeople(ctx, tx, movie.Actors)
+	if err != nil {
+		return err
+	}
+
+	if len(actorIDs) > 0 {
+		actors := make([]model.MovieActors, len(actorIDs))
+
+		for i, id := range actorIDs {
+			actors[i] = model.MovieActors{MovieID: movie.ID, PersonID: id}
+		}
+
+		_, err = table.MovieActors.INSERT(table.MovieActors.AllColumns).MODELS(actors).ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	countryIDs, err := insertCountries(ctx, tx, movie.Countries)
+	if err != nil {
+		return err
+	}
+
+	if len(countryIDs) > 0 {
+		countries := make([]model.MovieCountries, len(countryIDs))
+
+		for i, id := range countryIDs {
+			countries[i] = model.MovieCountries{MovieID: movie.ID, CountryID: id}
+		}
+
+		_, err = table.MovieCountries.INSERT(table.MovieCountries.AllColumns).MODELS(countries).ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	genreIDs, err := insertGenres(ctx, tx, movie.Genres)
+	if err != nil {
+		return err
+	}
+
+	if len(genreIDs) > 0 {
+		genres := make([]model.MovieGenres, len(genreIDs))
+
+		for i, id := range genreIDs {
+			genres[i] = model.MovieGenres{MovieID: movie.ID, GenreID: id}
+		}
+
+		_, err = table.MovieGenres.INSERT(table.MovieGenres.AllColumns).MODELS(genres).ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertPeople upserts people by name and returns their IDs.
+func insertPeople(ctx context.Context, tx *sql.Tx, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var people []model.People
+
+	insert := table.People.INSERT(table.People.Name)
+
+	for _, name := range names {
+		insert = insert.VALUES(name)
+	}
+
+	err := insert.
+		ON_CONFLICT(table.People.Name).
+		DO_UPDATE(sqlite.SET(table.People.Name.SET(table.People.EXCLUDED.Name))).
+		RETURNING(table.People.ID).
+		QueryContext(ctx, tx, &people)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(people))
+
+	for i, person := range people {
+		ids[i] = person.ID
+	}
+
+	return ids, nil
+}
+
+// insertCountries upserts countries by name and returns their IDs.
+func insertCountries(ctx context.Context, tx *sql.Tx, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var countries []model.Countries
+
+	insert := table.Countries.INSERT(table.Countries.Name)
+
+	for _, name := range names {
+		insert = insert.VALUES(name)
+	}
+
+	err := insert.
+		ON_CONFLICT(table.Countries.Name).
+		DO_UPDATE(sqlite.SET(table.Countries.Name.SET(table.Countries.EXCLUDED.Name))).
+		RETURNING(table.Countries.ID).
+		QueryContext(ctx, tx, &countries)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(countries))
+
+	for i, country := range countries {
+		ids[i] = country.ID
+	}
+
+	return ids, nil
+}
+
+// insertGenres upserts genres by name and returns their IDs.
+func insertGenres(ctx context.Context, tx *sql.Tx, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var genres []model.Genres
+
+	insert := table.Genres.INSERT(table.Genres.Name)
+
+	for _, name := range names {
+		insert = insert.VALUES(name)
+	}
+
+	err := insert.
+		ON_CONFLICT(table.Genres.Name).
+		DO_UPDATE(sqlite.SET(table.Genres.Name.SET(table.Genres.EXCLUDED.Name))).
+		RETURNING(table.Genres.ID).
+		QueryContext(ctx, tx, &genres)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(genres))
+
+	for i, genre := range genres {
+		ids[i] = genre.ID
+	}
+
+	return ids, nil
+}
+
+// arg binds value as a parameter without converting it to a jet literal.
+func arg(value any) sqlite.Expression {
+	return sqlite.Raw("#value", sqlite.RawArgs{"#value": value})
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) sqlite.BoolExpression {
+	condition := sqlite.Bool(true)
+
+	if query.Search != "" {
+		search := sqlite.RawInt("INSTR(people.name, #search)", sqlite.RawArgs{"#search": query.Search}).GT(sqlite.Int(0))
+
+		condition = condition.AND(sqlite.OR(
+			sqlite.EXISTS(sqlite.SELECT(sqlite.Int(1)).
+				FROM(table.MovieDirectors.INNER_JOIN(table.People, table.People.ID.EQ(table.MovieDirectors.PersonID))).
+				WHERE(table.MovieDirectors.MovieID.EQ(table.Movies.ID).AND(search))),
+			sqlite.EXISTS(sqlite.SELECT(sqlite.Int(1)).
+				FROM(table.MovieActors.INNER_JOIN(table.People, table.People.ID.EQ(table.MovieActors.PersonID))).
+				WHERE(table.MovieActors.MovieID.EQ(table.Movies.ID).AND(search))),
+		))
+	}
+
+	if query.Genre != "" {
+		condition = condition.AND(sqlite.EXISTS(sqlite.SELECT(sqlite.Int(1)).
+			FROM(table.MovieGenres.INNER_JOIN(table.Genres, table.Genres.ID.EQ(table.MovieGenres.GenreID))).
+			WHERE(table.MovieGenres.MovieID.EQ(table.Movies.ID).AND(table.Genres.Name.EQ(sqlite.String(query.Genre))))))
+	}
+
+	if query.Country != "" {
+		condition = condition.AND(sqlite.EXISTS(sqlite.SELECT(sqlite.Int(1)).
+			FROM(table.MovieCountries.INNER_JOIN(table.Countries, table.Countries.ID.EQ(table.MovieCountries.CountryID))).
+			WHERE(table.MovieCountries.MovieID.EQ(table.Movies.ID).AND(table.Countries.Name.EQ(sqlite.String(query.Country))))))
+	}
+
+	// Times are bound as they are, so that the driver formats them like the
+	// inserted values. jet's date literals are formatted differently.
+	if !query.AddedBefore.IsZero() {
+		condition = condition.AND(table.Movies.AddedAt.LT(sqlite.DateExp(arg(query.AddedBefore))))
+	}
+
+	if !query.AddedAfter.IsZero() {
+		condition = condition.AND(table.Movies.AddedAt.GT(sqlite.DateExp(arg(query.AddedAfter))))
+	}
+
+	if query.MinRating > 0 {
+		condition = condition.AND(table.Movies.Rating.GT_EQ(sqlite.Float(query.MinRating)))
+	}
+
+	if query.MaxRating > 0 {
+		condition = condition.AND(table.Movies.Rating.LT_EQ(sqlite.Float(query.MaxRating)))
+	}
+
+	return condition
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, names, column, alias string) sqlite.Projection {
+	return sqlite.Raw(fmt.Sprintf(
+		`(SELECT json_group_array(%[2]s.name ORDER BY %[2]s.name) FROM %[1]s JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s WHERE %[1]s.movie_id = movies.id)`,
+		links, names, column,
+	)).AS(alias)
+}
+
+var projections = sqlite.ProjectionList{
+	table.Movies.AllColumns,
+	aggregate("movie_directors", "people", "person_id", "movie.directors"),
+	aggregate("movie_actors", "people", "person_id", "movie.actors"),
+	aggregate("movie_countries", "countries", "country_id", "movie.countries"),
+	aggregate("movie_genres", "genres", "genre_id", "movie.genres"),
+}
+
+// Names scans a JSON array aggregated with json_group_array.
+type Names []string
+
+func (n *Names) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*n = nil
+
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), (*[]string)(n))
+	case []byte:
+		return json.Unmarshal(src, (*[]string)(n))
+	default:
+		return fmt.Errorf("jetflix: cannot scan %T into Names", src)
+	}
+}
+
+type Movie struct {
+	model.Movies
+
+	Directors Names `alias:"movie.directors"`
+	Actors    Names `alias:"movie.actors"`
+	Countries Names `alias:"movie.countries"`
+	Genres    Names `alias:"movie.genres"`
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	name, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	var column sqlite.Column
+
+	for _, c := range table.Movies.AllColumns {
+		if c.Name() == name {
+			column = c
+		}
+	}
+
+	condition := filter(query)
+
+	if query.After != nil {
+		keyset := sqlite.ROW(column, table.Movies.ID)
+		cursor := sqlite.ROW(arg(query.After.Value(query.Sort.Field)), sqlite.Int(query.After.ID))
+
+		if query.Sort.Descending {
+			condition = condition.AND(keyset.LT(cursor))
+		} else {
+			condition = condition.AND(keyset.GT(cursor))
+		}
+	}
+
+	stmt := sqlite.SELECT(projections).
+		FROM(table.Movies).
+		WHERE(condition)
+
+	if query.Sort.Descending {
+		stmt = stmt.ORDER_BY(column.DESC(), table.Movies.ID.DESC())
+	} else {
+		stmt = stmt.ORDER_BY(column.ASC(), table.Movies.ID.ASC())
+	}
+
+	if query.Limit > 0 {
+		stmt = stmt.LIMIT(int64(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT and jet omits
+			// negative limits.
+			stmt = stmt.LIMIT(math.MaxInt64)
+		}
+
+		stmt = stmt.OFFSET(int64(query.Offset))
+	}
+
+	var movies []Movie
+
+	if err = stmt.QueryContext(ctx, r.DB, &movies); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i] = ConvertMovie(movie)
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	err := sqlite.SELECT(projections).
+		FROM(table.Movies).
+		WHERE(table.Movies.ID.EQ(sqlite.Int(id))).
+		QueryContext(ctx, r.DB, &movie)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	return ConvertMovie(movie), nil
+}
+
+func ConvertMovie(movie Movie) benchflix.Movie {
+	return benchflix.Movie{
+		ID:        movie.ID,
+		Title:     movie.Title,
+		AddedAt:   movie.AddedAt,
+		Rating:    movie.Rating,
+		Directors: movie.Directors,
+		Actors:    movie.Actors,
+		Countries: movie.Countries,
+		Genres:    movie.Genres,
+	}
+}
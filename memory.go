@@ -0,0 +1,207 @@
+package benchflix
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryRepository is a Repository backed by a plain Go map. It defines the
+// expected behaviour of every other implementation and is used as the
+// reference in differential tests.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	movies map[int64]Movie
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		movies: map[int64]Movie{},
+	}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, movie Movie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.movies[movie.ID]; ok {
+		return ErrAlreadyExists
+	}
+
+	r.movies[movie.ID] = normalize(movie)
+
+	return nil
+}
+
+func (r *MemoryRepository) Read(ctx context.Context, id int64) (Movie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	movie, ok := r.movies[id]
+	if !ok {
+		return Movie{}, ErrNotFound
+	}
+
+	return movie, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, movie Movie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.movies[movie.ID]; !ok {
+		return ErrNotFound
+	}
+
+	r.movies[movie.ID] = normalize(movie)
+
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.movies[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.movies, id)
+
+	return nil
+}
+
+func (r *MemoryRepository) Query(ctx context.Context, query Query) ([]Movie, error) {
+	if _, err := query.Sort.Column(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+
+	var movies []Movie
+
+	for _, movie := range r.movies {
+		if query.Match(movie) {
+			movies = append(movies, movie)
+		}
+	}
+
+	r.mu.RUnlock()
+
+	slices.SortFunc(movies, func(a, b Movie) int {
+		return query.Sort.Compare(a, b)
+	})
+
+	if query.After != nil {
+		after := Movie{
+			ID:      query.After.ID,
+			Title:   query.After.Title,
+			AddedAt: query.After.AddedAt,
+			Rating:  query.After.Rating,
+		}
+
+		movies = slices.DeleteFunc(movies, func(movie Movie) bool {
+			return query.Sort.Compare(movie, after) <= 0
+		})
+	}
+
+	if query.Offset >= uint64(len(movies)) {
+		return nil, nil
+	}
+
+	movies = movies[query.Offset:]
+
+	if query.Limit > 0 && query.Limit < uint64(len(movies)) {
+		movies = movies[:query.Limit]
+	}
+
+	return movies, nil
+}
+
+// Match reports whether movie satisfies the filters of the query. Search is a
+// case-sensitive substring of a director or actor, the dates are exclusive and
+// the ratings inclusive bounds.
+func (q Query) Match(movie Movie) bool {
+	if q.Search != "" && !slices.ContainsFunc(movie.Directors, contains(q.Search)) &&
+		!slices.ContainsFunc(movie.Actors, contains(q.Search)) {
+		return false
+	}
+
+	if q.Genre != "" && !slices.Contains(movie.Genres, q.Genre) {
+		return false
+	}
+
+	if q.Country != "" && !slices.Contains(movie.Countries, q.Country) {
+		return false
+	}
+
+	if !q.AddedBefore.IsZero() && !movie.AddedAt.Before(q.AddedBefore) {
+		return false
+	}
+
+	if !q.AddedAfter.IsZero() && !movie.AddedAt.After(q.AddedAfter) {
+		return false
+	}
+
+	if q.MinRating > 0 && movie.Rating < q.MinRating {
+		return false
+	}
+
+	if q.MaxRating > 0 && movie.Rating > q.MaxRating {
+		return false
+	}
+
+	return true
+}
+
+// Compare orders two movies by the sort field and then by ID.
+func (s Sort) Compare(a, b Movie) int {
+	var c int
+
+	switch s.Field {
+	case SortByAddedAt:
+		c = a.AddedAt.Compare(b.AddedAt)
+	case SortByRating:
+		c = cmp.Compare(a.Rating, b.Rating)
+	case SortByID:
+	default:
+		c = strings.Compare(a.Title, b.Title)
+	}
+
+	if c == 0 {
+		c = cmp.Compare(a.ID, b.ID)
+	}
+
+	if s.Descending {
+		return -c
+	}
+
+	return c
+}
+
+func contains(substr string) func(string) bool {
+	return func(s string) bool {
+		return strings.Contains(s, substr)
+	}
+}
+
+// normalize sorts and deduplicates the names of a movie, like the databases
+// return them.
+func normalize(movie Movie) Movie {
+	movie.Directors = sortedNames(movie.Directors)
+	movie.Actors = sortedNames(movie.Actors)
+	movie.Countries = sortedNames(movie.Countries)
+	movie.Genres = sortedNames(movie.Genres)
+
+	return movie
+}
+
+func sortedNames(names []string) []string {
+	names = Unique(names)
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,63 @@
+package benchflix
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Factory opens a Repository on a SQLite data source name and creates its
+// schema.
+type Factory func(dsn string) (Repository, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{}
+)
+
+// Register makes an implementation available by name. It is meant to be called
+// from the init function of the implementing package and panics if the name is
+// registered twice or the factory is nil, like sql.Register.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("benchflix: Register factory is nil")
+	}
+
+	if _, dup := factories[name]; dup {
+		panic("benchflix: Register called twice for implementation " + name)
+	}
+
+	factories[name] = factory
+}
+
+// Implementations returns the sorted names of the registered implementations.
+func Implementations() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+
+	for name := range factories {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Open returns a new Repository of the named implementation.
+func Open(name, dsn string) (Repository, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[name]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("benchflix: unknown implementation %q (forgotten import?)", name)
+	}
+
+	return factory(dsn)
+}
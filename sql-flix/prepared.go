@@ -0,0 +1,168 @@
+package sqlflix
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// querier runs statements on a *sql.DB or *sql.Tx, or prepares them first.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// db returns the querier of the repository outside of a transaction.
+func (r Repository) db() querier {
+	if r.Statements == nil {
+		return r.DB
+	}
+
+	return prepared{statements: r.Statements}
+}
+
+// tx returns the querier of the repository within tx.
+func (r Repository) tx(tx *sql.Tx) querier {
+	if r.Statements == nil {
+		return tx
+	}
+
+	return prepared{statements: r.Statements, tx: tx}
+}
+
+// Statements prepares every distinct query once and reuses it. Queries that
+// are built with a variable number of rows or conditions get a statement per
+// shape. A query that is first used in a transaction is prepared on the
+// connection of the transaction and, after the commit, on the pool, so that
+// later transactions bind the statement with tx.StmtContext.
+type Statements struct {
+	db    *sql.DB
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+	// missed are the queries that transactions prepared for themselves.
+	missed []string
+}
+
+// Prepare returns the prepared statement of query.
+func (s *Statements) Prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	if stmt, ok := s.lookup(query); ok {
+		return stmt, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stmt, ok := s.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+func (s *Statements) lookup(query string) (*sql.Stmt, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stmt, ok := s.stmts[query]
+
+	return stmt, ok
+}
+
+// miss remembers a query that a transaction prepared for itself.
+func (s *Statements) miss(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.missed = append(s.missed, query)
+}
+
+// prepareMissed prepares the missed queries on the pool.
+func (s *Statements) prepareMissed(ctx context.Context) error {
+	s.mu.Lock()
+	missed := s.missed
+	s.missed = nil
+	s.mu.Unlock()
+
+	for _, query := range missed {
+		if _, err := s.Prepare(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit commits tx and, in prepared mode, prepares the queries that tx
+// prepared for itself, now that the pool has a free connection again.
+func (r Repository) commit(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil || r.Statements == nil {
+		return err
+	}
+
+	return r.Statements.prepareMissed(ctx)
+}
+
+// prepared runs queries as prepared statements, bound to tx if it is not nil.
+type prepared struct {
+	statements *Statements
+	tx         *sql.Tx
+}
+
+func (p prepared) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	if p.tx == nil {
+		return p.statements.Prepare(ctx, query)
+	}
+
+	if stmt, ok := p.statements.lookup(query); ok {
+		return p.tx.StmtContext(ctx, stmt), nil
+	}
+
+	// Preparing on the pool would take a second connection while tx holds
+	// one, which is another, empty database in memory. So the query is
+	// prepared on the connection of the transaction, closed with it, and
+	// prepared on the pool after the commit.
+	p.statements.miss(query)
+
+	return p.tx.PrepareContext(ctx, query)
+}
+
+func (p prepared) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (p prepared) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (p prepared) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		// A *sql.Row cannot be created with an error, so the unprepared query
+		// reports it instead.
+		if p.tx != nil {
+			return p.tx.QueryRowContext(ctx, query, args...)
+		}
+
+		return p.statements.db.QueryRowContext(ctx, query, args...)
+	}
+
+	return stmt.QueryRowContext(ctx, args...)
+}
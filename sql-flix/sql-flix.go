@@ -3,18 +3,38 @@ package sqlflix
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 
-	_ "github.com/mattn/go-sqlite3"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 )
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
+func init() {
+	drivers.Register("sql", NewRepository)
+	drivers.Register("sql-prepared", NewPreparedRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	return New(driverName, dataSourceName, false)
+}
+
+// NewPreparedRepository returns a Repository in prepared mode.
+func NewPreparedRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	return New(driverName, dataSourceName, true)
+}
+
+// New opens the database and creates the schema. In prepared mode, the
+// statements of Read, Create, Update and Delete are prepared right away and all
+// others the first time they are used.
+func New(driverName, dataSourceName string, prepared bool) (Repository, error) {
 	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return Repository{}, err
 	}
 
 	_, err = db.Exec(
@@ -64,32 +84,105 @@ func NewRepository(driverName, dataSourceName string) benchflix.Repository {
 			PRIMARY KEY (movie_id, genre_id)
 		);`)
 	if err != nil {
-		panic(err)
+		return Repository{}, err
 	}
 
-	return Repository{
-		DB: db,
+	if !prepared {
+		return Repository{
+			DB: db,
+		}, nil
+	}
+
+	statements := &Statements{
+		db:    db,
+		stmts: map[string]*sql.Stmt{},
+	}
+
+	for _, query := range []string{readMovie, insertMovie, updateMovie, deleteMovie} {
+		if _, err = statements.Prepare(context.Background(), query); err != nil {
+			return Repository{}, err
+		}
+	}
+
+	for _, table := range links {
+		if _, err = statements.Prepare(context.Background(), deleteLinks(table)); err != nil {
+			return Repository{}, err
+		}
 	}
+
+	return Repository{
+		DB:         db,
+		Statements: statements,
+	}, nil
 }
 
 type Repository struct {
 	DB *sql.DB
+	// Statements holds the prepared statements of DB. It is nil, unless the
+	// repository is in prepared mode.
+	Statements *Statements
+}
+
+const (
+	insertMovie = `INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?);`
+	updateMovie = `UPDATE movies SET title = ?, added_at = ?, rating = ? WHERE id = ?;`
+	deleteMovie = `DELETE FROM movies WHERE id = ?;`
+)
+
+// links are the tables that link movies to people, countries and genres.
+var links = []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"}
+
+func deleteLinks(table string) string {
+	return "DELETE FROM " + table + " WHERE movie_id = ?;"
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	_, err := r.DB.ExecContext(ctx, "DELETE FROM movies WHERE id = ?", id)
+	result, err := r.db().ExecContext(ctx, deleteMovie, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
 
-	return err
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
-	var (
-		actorsLen    = len(movie.Actors)
-		directorsLen = len(movie.Directors)
-		countriesLen = len(movie.Countries)
-		genresLen    = len(movie.Genres)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = r.commit(ctx, tx)
+		}
+	}()
+
+	_, err = r.tx(tx).ExecContext(ctx, insertMovie,
+		movie.ID, movie.Title, movie.AddedAt, movie.Rating,
 	)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
 
+	return insertRelations(ctx, r.tx(tx), movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -99,18 +192,207 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 		if err != nil {
 			err = errors.Join(err, tx.Rollback())
 		} else {
-			err = tx.Commit()
+			err = r.commit(ctx, tx)
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx,
-		`INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?);`,
-		movie.ID, movie.Title, movie.AddedAt, movie.Rating,
+	result, err := r.tx(tx).ExecContext(ctx, updateMovie,
+		movie.Title, movie.AddedAt, movie.Rating, movie.ID,
 	)
 	if err != nil {
 		return err
 	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range links {
+		if _, err = r.tx(tx).ExecContext(ctx, deleteLinks(table), movie.ID); err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, r.tx(tx), movie)
+}
+
+const batchSize = 1000
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = r.commit(ctx, tx)
+		}
+	}()
+
+	var people, countries, genres []string
+
+	for batch := range slices.Chunk(movies, batchSize) {
+		movieArgs := make([]any, 0, len(batch)*4)
+
+		for _, movie := range batch {
+			movieArgs = append(movieArgs, movie.ID, movie.Title, movie.AddedAt, movie.Rating)
+
+			people = append(append(people, movie.Directors...), movie.Actors...)
+			countries = append(countries, movie.Countries...)
+			genres = append(genres, movie.Genres...)
+		}
+
+		_, err = r.tx(tx).ExecContext(ctx,
+			fmt.Sprintf(
+				`INSERT INTO movies (id, title, added_at, rating) VALUES %s;`,
+				strings.Repeat(",(?, ?, ?, ?)", len(batch))[1:],
+			),
+			movieArgs...,
+		)
+		if err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+	}
+
+	personIDs, err := insertNames(ctx, r.tx(tx), "people", people)
+	if err != nil {
+		return err
+	}
+
+	countryIDs, err := insertNames(ctx, r.tx(tx), "countries", countries)
+	if err != nil {
+		return err
+	}
+
+	genreIDs, err := insertNames(ctx, r.tx(tx), "genres", genres)
+	if err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_directors (movie_id, person_id)", movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Directors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_actors (movie_id, person_id)", movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Actors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_countries (movie_id, country_id)", movies, countryIDs,
+		func(movie benchflix.Movie) []string { return movie.Countries }); err != nil {
+		return err
+	}
+
+	return insertLinks(ctx, r.tx(tx), "movie_genres (movie_id, genre_id)", movies, genreIDs,
+		func(movie benchflix.Movie) []string { return movie.Genres })
+}
+
+func insertNames(ctx context.Context, tx querier, table string, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+
+	for batch := range slices.Chunk(benchflix.Unique(names), batchSize) {
+		args := make([]any, len(batch))
+
+		for i, name := range batch {
+			args[i] = name
+		}
+
+		rows, err := tx.QueryContext(ctx,
+			fmt.Sprintf(
+				`INSERT INTO %s (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name;`,
+				table, strings.Repeat(",(?)", len(args))[1:],
+			),
+			args...,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var (
+				id   int64
+				name string
+			)
+
+			if err = rows.Scan(&id, &name); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+
+			ids[name] = id
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+func insertLinks(
+	ctx context.Context,
+	tx querier,
+	table string,
+	movies []benchflix.Movie,
+	ids map[string]int64,
+	names func(benchflix.Movie) []string,
+) error {
+	args := make([]any, 0, batchSize*2)
+
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx,
+			fmt.Sprintf(
+				`INSERT INTO %s VALUES %s;`,
+				table, strings.Repeat(",(?, ?)", len(args)/2)[1:],
+			),
+			args...,
+		)
+
+		args = args[:0]
+
+		return err
+	}
+
+	for _, movie := range movies {
+		for _, name := range names(movie) {
+			args = append(args, movie.ID, ids[name])
+
+			if len(args) == batchSize*2 {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return flush()
+}
+
+func insertRelations(ctx context.Context, tx querier, movie benchflix.Movie) (err error) {
+	var (
+		actorsLen    = len(movie.Actors)
+		directorsLen = len(movie.Directors)
+		countriesLen = len(movie.Countries)
+		genresLen    = len(movie.Genres)
+	)
+
 	if actorsLen+directorsLen > 0 {
 		peopleArgs := make([]any, directorsLen+actorsLen)
 
@@ -291,7 +573,8 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 	return nil
 }
 
-func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+// filter writes the conditions of query to a WHERE clause of the movies table.
+func filter(query benchflix.Query) (*strings.Builder, []any) {
 	builder := &strings.Builder{}
 	args := []any{}
 
@@ -356,142 +639,309 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		args = append(args, query.MaxRating)
 	}
 
-	builder.WriteString(" ORDER BY movies.title ASC")
+	return builder, args
+}
 
-	if query.Limit > 0 {
-		builder.WriteString(" LIMIT ?")
-		
-		args = append(args, query.Limit)
-	}
+// selectMovies returns the statement and arguments of query.
+func selectMovies(query benchflix.Query) (string, []any, error) {
+	builder, args := filter(query)
 
-	rows, err := r.DB.QueryContext(ctx,
-		fmt.Sprintf(
-			`SELECT
-				movies.id,
-				movies.title,
-				movies.added_at,
-				movies.rating,
-				(
-					SELECT GROUP_CONCAT(people.name ORDER BY people.name)
-					FROM movie_directors
-					JOIN people ON people.id = movie_directors.person_id
-					WHERE movie_directors.movie_id = movies.id
-				) AS directors,
-				(
-					SELECT GROUP_CONCAT(people.name ORDER BY people.name)
-					FROM movie_actors
-					JOIN people ON people.id = movie_actors.person_id
-					WHERE movie_actors.movie_id = movies.id
-				) AS actors,
-				(
-					SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
-					FROM movie_countries
-					JOIN countries ON countries.id = movie_countries.country_id
-					WHERE movie_countries.movie_id = movies.id
-				) AS countries,
-				(
-					SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
-					FROM movie_genres
-					JOIN genres ON genres.id = movie_genres.genre_id
-					WHERE movie_genres.movie_id = movies.id
-				) AS genres
-			FROM movies
-			WHERE 1=1 %s;`,
-			builder,
-		),
-		args...,
-	)
+	column, err := query.Sort.Column()
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
-	defer func() {
-		err = errors.Join(err, rows.Err(), rows.Close())
-	}()
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
 
-	var movies []benchflix.Movie
+		fmt.Fprintf(builder, ` AND (movies.%s, movies.id) %s (?, ?)`, column, operator)
 
-	for rows.Next() {
-		var (
-			movie                                benchflix.Movie
-			directors, actors, countries, genres sql.NullString
-		)
+		args = append(args, query.After.Value(query.Sort.Field), query.After.ID)
+	}
 
-		if err := rows.Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres); err != nil {
-			return nil, err
-		}
+	fmt.Fprintf(builder, " ORDER BY movies.%s %s, movies.id %s", column, query.Sort.Direction(), query.Sort.Direction())
 
-		movies = append(movies, ConvertMovie(movie, directors, actors, countries, genres))
+	if query.Limit > 0 {
+		builder.WriteString(" LIMIT ?")
+		
+		args = append(args, query.Limit)
 	}
 
-	return movies, nil
-}
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			builder.WriteString(" LIMIT -1")
+		}
 
-func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
-	row := r.DB.QueryRowContext(ctx,
+		builder.WriteString(" OFFSET ?")
+
+		args = append(args, query.Offset)
+	}
+
+	return fmt.Sprintf(
 		`SELECT
 			movies.id,
 			movies.title,
 			movies.added_at,
 			movies.rating,
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_directors
 				JOIN people ON people.id = movie_directors.person_id
 				WHERE movie_directors.movie_id = movies.id
 			) AS directors,
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_actors
 				JOIN people ON people.id = movie_actors.person_id
 				WHERE movie_actors.movie_id = movies.id
 			) AS actors,
 			(
-				SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
+				SELECT json_group_array(countries.name ORDER BY countries.name)
 				FROM movie_countries
 				JOIN countries ON countries.id = movie_countries.country_id
 				WHERE movie_countries.movie_id = movies.id
 			) AS countries,
 			(
-				SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
+				SELECT json_group_array(genres.name ORDER BY genres.name)
 				FROM movie_genres
 				JOIN genres ON genres.id = movie_genres.genre_id
 				WHERE movie_genres.movie_id = movies.id
 			) AS genres
 		FROM movies
-		WHERE id = ?
-		ORDER BY movies.title ASC;`,
-		id,
+		WHERE 1=1 %s;`,
+		builder,
+	), args, nil
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	text, args, err := selectMovies(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db().QueryContext(ctx, text, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = errors.Join(err, rows.Err(), rows.Close())
+	}()
+
+	var movies []benchflix.Movie
+
+	for rows.Next() {
+		var (
+			movie                                benchflix.Movie
+			directors, actors, countries, genres []byte
+		)
+
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres); err != nil {
+			return nil, err
+		}
+
+		movie, err = ConvertMovie(movie, directors, actors, countries, genres)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies, nil
+}
+
+func (r Repository) Stream(ctx context.Context, query benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		text, args, err := selectMovies(query)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		rows, err := r.db().QueryContext(ctx, text, args...)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				movie                                benchflix.Movie
+				directors, actors, countries, genres []byte
+			)
+
+			if err = rows.Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres); err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			movie, err = ConvertMovie(movie, directors, actors, countries, genres)
+			if err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			if !yield(movie, nil) {
+				return
+			}
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			yield(benchflix.Movie{}, err)
+		}
+	}
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	builder, args := filter(query)
+
+	var facets benchflix.Facets
+
+	err := r.db().QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM movies WHERE 1=1 %s;`, builder), args...).Scan(&facets.Count)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	facets.Genres, err = r.facetCounts(ctx,
+		fmt.Sprintf(
+			`SELECT genres.name, COUNT(*)
+			FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			JOIN movies ON movies.id = movie_genres.movie_id
+			WHERE 1=1 %s
+			GROUP BY genres.name
+			ORDER BY COUNT(*) DESC, genres.name ASC;`,
+			builder,
+		),
+		args...,
 	)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	facets.Countries, err = r.facetCounts(ctx,
+		fmt.Sprintf(
+			`SELECT countries.name, COUNT(*)
+			FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			JOIN movies ON movies.id = movie_countries.movie_id
+			WHERE 1=1 %s
+			GROUP BY countries.name
+			ORDER BY COUNT(*) DESC, countries.name ASC;`,
+			builder,
+		),
+		args...,
+	)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	return facets, nil
+}
+
+func (r Repository) facetCounts(ctx context.Context, query string, args ...any) (counts []benchflix.FacetCount, err error) {
+	rows, err := r.db().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = errors.Join(err, rows.Err(), rows.Close())
+	}()
+
+	for rows.Next() {
+		var count benchflix.FacetCount
+
+		if err = rows.Scan(&count.Name, &count.Count); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+const readMovie = `SELECT
+		movies.id,
+		movies.title,
+		movies.added_at,
+		movies.rating,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_directors
+			JOIN people ON people.id = movie_directors.person_id
+			WHERE movie_directors.movie_id = movies.id
+		) AS directors,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_actors
+			JOIN people ON people.id = movie_actors.person_id
+			WHERE movie_actors.movie_id = movies.id
+		) AS actors,
+		(
+			SELECT json_group_array(countries.name ORDER BY countries.name)
+			FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id
+		) AS countries,
+		(
+			SELECT json_group_array(genres.name ORDER BY genres.name)
+			FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id
+		) AS genres
+	FROM movies
+	WHERE id = ?
+	ORDER BY movies.title ASC;`
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	row := r.db().QueryRowContext(ctx, readMovie, id)
 
 	var (
 		movie                                benchflix.Movie
-		directors, actors, countries, genres sql.NullString
+		directors, actors, countries, genres []byte
 	)
 
 	if err := row.Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
-	return ConvertMovie(movie, directors, actors, countries, genres), nil
+	return ConvertMovie(movie, directors, actors, countries, genres)
 }
 
-func ConvertMovie(movie benchflix.Movie, directors, actors, countries, genres sql.NullString) benchflix.Movie {
-	if directors.Valid {
-		movie.Directors = strings.Split(directors.String, ",")
+// ConvertMovie decodes the names aggregated with json_group_array. Unlike
+// GROUP_CONCAT, a JSON array keeps names containing commas intact.
+func ConvertMovie(movie benchflix.Movie, directors, actors, countries, genres []byte) (benchflix.Movie, error) {
+	if err := json.Unmarshal(directors, &movie.Directors); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if actors.Valid {
-		movie.Actors = strings.Split(actors.String, ",")
+	if err := json.Unmarshal(actors, &movie.Actors); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if countries.Valid {
-		movie.Countries = strings.Split(countries.String, ",")
+	if err := json.Unmarshal(countries, &movie.Countries); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if genres.Valid {
-		movie.Genres = strings.Split(genres.String, ",")
+	if err := json.Unmarshal(genres, &movie.Genres); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	return movie
+	return movie, nil
 }
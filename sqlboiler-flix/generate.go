@@ -0,0 +1,98 @@
+//go:build ignore
+
+// generate creates the schema of sqlc-flix in a temporary database file and
+// generates the sqlboiler models from it into ./internal/models. NUMERIC columns
+// are mapped to float64 instead of a decimal type, like in the other
+// implementations, and the two join tables to people get distinct relationship
+// names.
+package main
+
+import (
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/aarondl/sqlboiler/v4/boilingcore"
+	"github.com/aarondl/sqlboiler/v4/drivers"
+	_ "github.com/aarondl/sqlboiler/v4/drivers/sqlboiler-sqlite3/driver"
+	"github.com/aarondl/sqlboiler/v4/importers"
+	_ "github.com/mattn/go-sqlite3"
+	sqlcflix "github.com/wroge/bench-flix/sqlc-flix"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "sqlboiler-flix")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	dbname := filepath.Join(dir, "flix.db")
+
+	db, err := sql.Open("sqlite3", dbname)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = db.Exec(sqlcflix.Schema); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = db.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	state, err := boilingcore.New(&boilingcore.Config{
+		DriverName: "sqlite3",
+		DriverConfig: drivers.Config{
+			drivers.ConfigDBName:      dbname,
+			drivers.ConfigForeignKeys: []drivers.ForeignKey{},
+		},
+		PkgName:   "models",
+		OutFolder: "internal/models",
+		NoTests:   true,
+		NoHooks:   true,
+		Wipe:      true,
+		StructTagCases: boilingcore.StructTagCases{
+			Json: "snake",
+			Yaml: "snake",
+			Toml: "snake",
+			Boil: "snake",
+		},
+		Imports: importers.NewDefaultImports(),
+		Aliases: boilingcore.Aliases{
+			Tables: map[string]boilingcore.TableAlias{
+				"movie_directors": joinAlias("Directors", "DirectedMovies"),
+				"movie_actors":    joinAlias("Actors", "ActedMovies"),
+			},
+		},
+		TypeReplaces: []boilingcore.TypeReplace{{
+			Match:   drivers.Column{DBType: "NUMERIC"},
+			Replace: drivers.Column{Type: "float64"},
+		}},
+		Version: "4.19.5",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer state.Cleanup()
+
+	if err = state.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// joinAlias names both sides of a join table between movies and people, which
+// would both be called People and Movies otherwise. FK_1 references movies and
+// FK_0 people.
+func joinAlias(people, movies string) boilingcore.TableAlias {
+	return boilingcore.TableAlias{
+		Relationships: map[string]boilingcore.RelationshipAlias{
+			"FK_1": {Local: people, Foreign: movies},
+			"FK_0": {Local: movies, Foreign: people},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+var TableNames = struct {
+	Countries      string
+	Genres         string
+	MovieActors    string
+	MovieCountries string
+	MovieDirectors string
+	MovieGenres    string
+	Movies         string
+	People         string
+}{
+	Countries:      "countries",
+	Genres:         "genres",
+	MovieActors:    "movie_actors",
+	MovieCountries: "movie_countries",
+	MovieDirectors: "movie_directors",
+	MovieGenres:    "movie_genres",
+	Movies:         "movies",
+	People:         "people",
+}
@@ -0,0 +1,976 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// Genre is an object representing the database table.
+type Genre struct {
+	ID   null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
+	Name string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *genreR `boil:"" json:"" toml:"" yaml:""`
+	L genreL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var GenreColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "id",
+	Name: "name",
+}
+
+var GenreTableColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "genres.id",
+	Name: "genres.name",
+}
+
+// Generated where
+
+var GenreWhere = struct {
+	ID   whereHelpernull_Int64
+	Name whereHelperstring
+}{
+	ID:   whereHelpernull_Int64{field: "\"genres\".\"id\""},
+	Name: whereHelperstring{field: "\"genres\".\"name\""},
+}
+
+// GenreRels is where relationship names are stored.
+var GenreRels = struct {
+	Movies string
+}{
+	Movies: "Movies",
+}
+
+// genreR is where relationships are stored.
+type genreR struct {
+	Movies MovieSlice `boil:"Movies" json:"Movies" toml:"Movies" yaml:"Movies"`
+}
+
+// NewStruct creates a new relationship struct
+func (*genreR) NewStruct() *genreR {
+	return &genreR{}
+}
+
+func (o *Genre) GetMovies() MovieSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetMovies()
+}
+
+func (r *genreR) GetMovies() MovieSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.Movies
+}
+
+// genreL is where Load methods for each relationship are stored.
+type genreL struct{}
+
+var (
+	genreAllColumns            = []string{"id", "name"}
+	genreColumnsWithoutDefault = []string{"name"}
+	genreColumnsWithDefault    = []string{"id"}
+	genrePrimaryKeyColumns     = []string{"id"}
+	genreGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// GenreSlice is an alias for a slice of pointers to Genre.
+	// This should almost always be used instead of []Genre.
+	GenreSlice []*Genre
+
+	genreQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	genreType                 = reflect.TypeOf(&Genre{})
+	genreMapping              = queries.MakeStructMapping(genreType)
+	genrePrimaryKeyMapping, _ = queries.BindMapping(genreType, genreMapping, genrePrimaryKeyColumns)
+	genreInsertCacheMut       sync.RWMutex
+	genreInsertCache          = make(map[string]insertCache)
+	genreUpdateCacheMut       sync.RWMutex
+	genreUpdateCache          = make(map[string]updateCache)
+	genreUpsertCacheMut       sync.RWMutex
+	genreUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single genre record from the query.
+func (q genreQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Genre, error) {
+	o := &Genre{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for genres")
+	}
+
+	return o, nil
+}
+
+// All returns all Genre records from the query.
+func (q genreQuery) All(ctx context.Context, exec boil.ContextExecutor) (GenreSlice, error) {
+	var o []*Genre
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Genre slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Genre records in the query.
+func (q genreQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count genres rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q genreQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if genres exists")
+	}
+
+	return count > 0, nil
+}
+
+// Movies retrieves all the movie's Movies with an executor.
+func (o *Genre) Movies(mods ...qm.QueryMod) movieQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_genres\" on \"movies\".\"id\" = \"movie_genres\".\"movie_id\""),
+		qm.Where("\"movie_genres\".\"genre_id\"=?", o.ID),
+	)
+
+	return Movies(queryMods...)
+}
+
+// LoadMovies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (genreL) LoadMovies(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGenre interface{}, mods queries.Applicator) error {
+	var slice []*Genre
+	var object *Genre
+
+	if singular {
+		var ok bool
+		object, ok = maybeGenre.(*Genre)
+		if !ok {
+			object = new(Genre)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeGenre)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeGenre))
+			}
+		}
+	} else {
+		s, ok := maybeGenre.(*[]*Genre)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeGenre)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeGenre))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &genreR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &genreR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"movies\".\"id\", \"movies\".\"title\", \"movies\".\"added_at\", \"movies\".\"rating\", \"a\".\"genre_id\""),
+		qm.From("\"movies\""),
+		qm.InnerJoin("\"movie_genres\" as \"a\" on \"movies\".\"id\" = \"a\".\"movie_id\""),
+		qm.WhereIn("\"a\".\"genre_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load movies")
+	}
+
+	var resultSlice []*Movie
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Movie)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Title, &one.AddedAt, &one.Rating, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for movies")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice movies")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on movies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for movies")
+	}
+
+	if singular {
+		object.R.Movies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &movieR{}
+			}
+			foreign.R.Genres = append(foreign.R.Genres, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Movies = append(local.R.Movies, foreign)
+				if foreign.R == nil {
+					foreign.R = &movieR{}
+				}
+				foreign.R.Genres = append(foreign.R.Genres, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddMovies adds the given related objects to the existing relationships
+// of the genre, optionally inserting them as new records.
+// Appends related to o.R.Movies.
+// Sets related.R.Genres appropriately.
+func (o *Genre) AddMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_genres\" (\"genre_id\", \"movie_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &genreR{
+			Movies: related,
+		}
+	} else {
+		o.R.Movies = append(o.R.Movies, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &movieR{
+				Genres: GenreSlice{o},
+			}
+		} else {
+			rel.R.Genres = append(rel.R.Genres, o)
+		}
+	}
+	return nil
+}
+
+// SetMovies removes all previously related items of the
+// genre replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Genres's Movies accordingly.
+// Replaces o.R.Movies with related.
+// Sets related.R.Genres's Movies accordingly.
+func (o *Genre) SetMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	query := "delete from \"movie_genres\" where \"genre_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeMoviesFromGenresSlice(o, related)
+	if o.R != nil {
+		o.R.Movies = nil
+	}
+
+	return o.AddMovies(ctx, exec, insert, related...)
+}
+
+// RemoveMovies relationships from objects passed in.
+// Removes related items from R.Movies (uses pointer comparison, removal does not keep order)
+// Sets related.R.Genres.
+func (o *Genre) RemoveMovies(ctx context.Context, exec boil.ContextExecutor, related ...*Movie) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_genres\" where \"genre_id\" = ? and \"movie_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeMoviesFromGenresSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Movies {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Movies)
+			if ln > 1 && i < ln-1 {
+				o.R.Movies[i] = o.R.Movies[ln-1]
+			}
+			o.R.Movies = o.R.Movies[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeMoviesFromGenresSlice(o *Genre, related []*Movie) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Genres {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Genres)
+			if ln > 1 && i < ln-1 {
+				rel.R.Genres[i] = rel.R.Genres[ln-1]
+			}
+			rel.R.Genres = rel.R.Genres[:ln-1]
+			break
+		}
+	}
+}
+
+// Genres retrieves all the records using an executor.
+func Genres(mods ...qm.QueryMod) genreQuery {
+	mods = append(mods, qm.From("\"genres\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"genres\".*"})
+	}
+
+	return genreQuery{q}
+}
+
+// FindGenre retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindGenre(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Genre, error) {
+	genreObj := &Genre{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"genres\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, genreObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from genres")
+	}
+
+	return genreObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Genre) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no genres provided for insertion")
+	}
+
+	var err error
+
+	nzDefaults := queries.NonZeroDefaultSet(genreColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	genreInsertCacheMut.RLock()
+	cache, cached := genreInsertCache[key]
+	genreInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			genreAllColumns,
+			genreColumnsWithDefault,
+			genreColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, genreGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(genreType, genreMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(genreType, genreMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"genres\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"genres\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into genres")
+	}
+
+	if !cached {
+		genreInsertCacheMut.Lock()
+		genreInsertCache[key] = cache
+		genreInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Genre.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Genre) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	genreUpdateCacheMut.RLock()
+	cache, cached := genreUpdateCache[key]
+	genreUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			genreAllColumns,
+			genrePrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, genreGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update genres, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"genres\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, genrePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(genreType, genreMapping, append(wl, genrePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update genres row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for genres")
+	}
+
+	if !cached {
+		genreUpdateCacheMut.Lock()
+		genreUpdateCache[key] = cache
+		genreUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q genreQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for genres")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for genres")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o GenreSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), genrePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"genres\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, genrePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in genre slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all genre")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Genre) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no genres provided for upsert")
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(genreColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	genreUpsertCacheMut.RLock()
+	cache, cached := genreUpsertCache[key]
+	genreUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			genreAllColumns,
+			genreColumnsWithDefault,
+			genreColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			genreAllColumns,
+			genrePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert genres, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(genreAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(genrePrimaryKeyColumns))
+			copy(conflict, genrePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"genres\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(genreType, genreMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(genreType, genreMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert genres")
+	}
+
+	if !cached {
+		genreUpsertCacheMut.Lock()
+		genreUpsertCache[key] = cache
+		genreUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Genre record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Genre) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Genre provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), genrePrimaryKeyMapping)
+	sql := "DELETE FROM \"genres\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from genres")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for genres")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q genreQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no genreQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from genres")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for genres")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o GenreSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), genrePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"genres\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, genrePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from genre slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for genres")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Genre) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindGenre(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *GenreSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := GenreSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), genrePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"genres\".* FROM \"genres\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, genrePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in GenreSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// GenreExists checks if the Genre row exists.
+func GenreExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"genres\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if genres exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Genre row exists.
+func (o *Genre) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return GenreExists(ctx, exec, o.ID)
+}
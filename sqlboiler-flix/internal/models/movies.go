@@ -0,0 +1,1946 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// Movie is an object representing the database table.
+type Movie struct {
+	ID      null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
+	Title   string     `boil:"title" json:"title" toml:"title" yaml:"title"`
+	AddedAt time.Time  `boil:"added_at" json:"added_at" toml:"added_at" yaml:"added_at"`
+	Rating  float64    `boil:"rating" json:"rating" toml:"rating" yaml:"rating"`
+
+	R *movieR `boil:"" json:"" toml:"" yaml:""`
+	L movieL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var MovieColumns = struct {
+	ID      string
+	Title   string
+	AddedAt string
+	Rating  string
+}{
+	ID:      "id",
+	Title:   "title",
+	AddedAt: "added_at",
+	Rating:  "rating",
+}
+
+var MovieTableColumns = struct {
+	ID      string
+	Title   string
+	AddedAt string
+	Rating  string
+}{
+	ID:      "movies.id",
+	Title:   "movies.title",
+	AddedAt: "movies.added_at",
+	Rating:  "movies.rating",
+}
+
+// Generated where
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelperfloat64 struct{ field string }
+
+func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperfloat64) NEQ(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelperfloat64) LT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperfloat64) LTE(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelperfloat64) GT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperfloat64) GTE(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelperfloat64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperfloat64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var MovieWhere = struct {
+	ID      whereHelpernull_Int64
+	Title   whereHelperstring
+	AddedAt whereHelpertime_Time
+	Rating  whereHelperfloat64
+}{
+	ID:      whereHelpernull_Int64{field: "\"movies\".\"id\""},
+	Title:   whereHelperstring{field: "\"movies\".\"title\""},
+	AddedAt: whereHelpertime_Time{field: "\"movies\".\"added_at\""},
+	Rating:  whereHelperfloat64{field: "\"movies\".\"rating\""},
+}
+
+// MovieRels is where relationship names are stored.
+var MovieRels = struct {
+	Actors    string
+	Countries string
+	Directors string
+	Genres    string
+}{
+	Actors:    "Actors",
+	Countries: "Countries",
+	Directors: "Directors",
+	Genres:    "Genres",
+}
+
+// movieR is where relationships are stored.
+type movieR struct {
+	Actors    PersonSlice  `boil:"Actors" json:"Actors" toml:"Actors" yaml:"Actors"`
+	Countries CountrySlice `boil:"Countries" json:"Countries" toml:"Countries" yaml:"Countries"`
+	Directors PersonSlice  `boil:"Directors" json:"Directors" toml:"Directors" yaml:"Directors"`
+	Genres    GenreSlice   `boil:"Genres" json:"Genres" toml:"Genres" yaml:"Genres"`
+}
+
+// NewStruct creates a new relationship struct
+func (*movieR) NewStruct() *movieR {
+	return &movieR{}
+}
+
+func (o *Movie) GetActors() PersonSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetActors()
+}
+
+func (r *movieR) GetActors() PersonSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.Actors
+}
+
+func (o *Movie) GetCountries() CountrySlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetCountries()
+}
+
+func (r *movieR) GetCountries() CountrySlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.Countries
+}
+
+func (o *Movie) GetDirectors() PersonSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetDirectors()
+}
+
+func (r *movieR) GetDirectors() PersonSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.Directors
+}
+
+func (o *Movie) GetGenres() GenreSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetGenres()
+}
+
+func (r *movieR) GetGenres() GenreSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.Genres
+}
+
+// movieL is where Load methods for each relationship are stored.
+type movieL struct{}
+
+var (
+	movieAllColumns            = []string{"id", "title", "added_at", "rating"}
+	movieColumnsWithoutDefault = []string{"title", "added_at", "rating"}
+	movieColumnsWithDefault    = []string{"id"}
+	moviePrimaryKeyColumns     = []string{"id"}
+	movieGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// MovieSlice is an alias for a slice of pointers to Movie.
+	// This should almost always be used instead of []Movie.
+	MovieSlice []*Movie
+
+	movieQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	movieType                 = reflect.TypeOf(&Movie{})
+	movieMapping              = queries.MakeStructMapping(movieType)
+	moviePrimaryKeyMapping, _ = queries.BindMapping(movieType, movieMapping, moviePrimaryKeyColumns)
+	movieInsertCacheMut       sync.RWMutex
+	movieInsertCache          = make(map[string]insertCache)
+	movieUpdateCacheMut       sync.RWMutex
+	movieUpdateCache          = make(map[string]updateCache)
+	movieUpsertCacheMut       sync.RWMutex
+	movieUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single movie record from the query.
+func (q movieQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Movie, error) {
+	o := &Movie{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for movies")
+	}
+
+	return o, nil
+}
+
+// All returns all Movie records from the query.
+func (q movieQuery) All(ctx context.Context, exec boil.ContextExecutor) (MovieSlice, error) {
+	var o []*Movie
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Movie slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Movie records in the query.
+func (q movieQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count movies rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q movieQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if movies exists")
+	}
+
+	return count > 0, nil
+}
+
+// Actors retrieves all the person's People with an executor via id column.
+func (o *Movie) Actors(mods ...qm.QueryMod) personQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_actors\" on \"people\".\"id\" = \"movie_actors\".\"person_id\""),
+		qm.Where("\"movie_actors\".\"movie_id\"=?", o.ID),
+	)
+
+	return People(queryMods...)
+}
+
+// Countries retrieves all the country's Countries with an executor.
+func (o *Movie) Countries(mods ...qm.QueryMod) countryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_countries\" on \"countries\".\"id\" = \"movie_countries\".\"country_id\""),
+		qm.Where("\"movie_countries\".\"movie_id\"=?", o.ID),
+	)
+
+	return Countries(queryMods...)
+}
+
+// Directors retrieves all the person's People with an executor via id column.
+func (o *Movie) Directors(mods ...qm.QueryMod) personQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_directors\" on \"people\".\"id\" = \"movie_directors\".\"person_id\""),
+		qm.Where("\"movie_directors\".\"movie_id\"=?", o.ID),
+	)
+
+	return People(queryMods...)
+}
+
+// Genres retrieves all the genre's Genres with an executor.
+func (o *Movie) Genres(mods ...qm.QueryMod) genreQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_genres\" on \"genres\".\"id\" = \"movie_genres\".\"genre_id\""),
+		qm.Where("\"movie_genres\".\"movie_id\"=?", o.ID),
+	)
+
+	return Genres(queryMods...)
+}
+
+// LoadActors allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (movieL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMovie interface{}, mods queries.Applicator) error {
+	var slice []*Movie
+	var object *Movie
+
+	if singular {
+		var ok bool
+		object, ok = maybeMovie.(*Movie)
+		if !ok {
+			object = new(Movie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMovie))
+			}
+		}
+	} else {
+		s, ok := maybeMovie.(*[]*Movie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &movieR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &movieR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"people\".\"id\", \"people\".\"name\", \"a\".\"movie_id\""),
+		qm.From("\"people\""),
+		qm.InnerJoin("\"movie_actors\" as \"a\" on \"people\".\"id\" = \"a\".\"person_id\""),
+		qm.WhereIn("\"a\".\"movie_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load people")
+	}
+
+	var resultSlice []*Person
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Person)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for people")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice people")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on people")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for people")
+	}
+
+	if singular {
+		object.R.Actors = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &personR{}
+			}
+			foreign.R.ActedMovies = append(foreign.R.ActedMovies, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Actors = append(local.R.Actors, foreign)
+				if foreign.R == nil {
+					foreign.R = &personR{}
+				}
+				foreign.R.ActedMovies = append(foreign.R.ActedMovies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCountries allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (movieL) LoadCountries(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMovie interface{}, mods queries.Applicator) error {
+	var slice []*Movie
+	var object *Movie
+
+	if singular {
+		var ok bool
+		object, ok = maybeMovie.(*Movie)
+		if !ok {
+			object = new(Movie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMovie))
+			}
+		}
+	} else {
+		s, ok := maybeMovie.(*[]*Movie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &movieR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &movieR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"countries\".\"id\", \"countries\".\"name\", \"a\".\"movie_id\""),
+		qm.From("\"countries\""),
+		qm.InnerJoin("\"movie_countries\" as \"a\" on \"countries\".\"id\" = \"a\".\"country_id\""),
+		qm.WhereIn("\"a\".\"movie_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load countries")
+	}
+
+	var resultSlice []*Country
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Country)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for countries")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice countries")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on countries")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for countries")
+	}
+
+	if singular {
+		object.R.Countries = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &countryR{}
+			}
+			foreign.R.Movies = append(foreign.R.Movies, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Countries = append(local.R.Countries, foreign)
+				if foreign.R == nil {
+					foreign.R = &countryR{}
+				}
+				foreign.R.Movies = append(foreign.R.Movies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadDirectors allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (movieL) LoadDirectors(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMovie interface{}, mods queries.Applicator) error {
+	var slice []*Movie
+	var object *Movie
+
+	if singular {
+		var ok bool
+		object, ok = maybeMovie.(*Movie)
+		if !ok {
+			object = new(Movie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMovie))
+			}
+		}
+	} else {
+		s, ok := maybeMovie.(*[]*Movie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &movieR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &movieR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"people\".\"id\", \"people\".\"name\", \"a\".\"movie_id\""),
+		qm.From("\"people\""),
+		qm.InnerJoin("\"movie_directors\" as \"a\" on \"people\".\"id\" = \"a\".\"person_id\""),
+		qm.WhereIn("\"a\".\"movie_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load people")
+	}
+
+	var resultSlice []*Person
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Person)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for people")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice people")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on people")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for people")
+	}
+
+	if singular {
+		object.R.Directors = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &personR{}
+			}
+			foreign.R.DirectedMovies = append(foreign.R.DirectedMovies, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Directors = append(local.R.Directors, foreign)
+				if foreign.R == nil {
+					foreign.R = &personR{}
+				}
+				foreign.R.DirectedMovies = append(foreign.R.DirectedMovies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadGenres allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (movieL) LoadGenres(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMovie interface{}, mods queries.Applicator) error {
+	var slice []*Movie
+	var object *Movie
+
+	if singular {
+		var ok bool
+		object, ok = maybeMovie.(*Movie)
+		if !ok {
+			object = new(Movie)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMovie))
+			}
+		}
+	} else {
+		s, ok := maybeMovie.(*[]*Movie)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMovie)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMovie))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &movieR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &movieR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"genres\".\"id\", \"genres\".\"name\", \"a\".\"movie_id\""),
+		qm.From("\"genres\""),
+		qm.InnerJoin("\"movie_genres\" as \"a\" on \"genres\".\"id\" = \"a\".\"genre_id\""),
+		qm.WhereIn("\"a\".\"movie_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load genres")
+	}
+
+	var resultSlice []*Genre
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Genre)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for genres")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice genres")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on genres")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for genres")
+	}
+
+	if singular {
+		object.R.Genres = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &genreR{}
+			}
+			foreign.R.Movies = append(foreign.R.Movies, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Genres = append(local.R.Genres, foreign)
+				if foreign.R == nil {
+					foreign.R = &genreR{}
+				}
+				foreign.R.Movies = append(foreign.R.Movies, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddActors adds the given related objects to the existing relationships
+// of the movie, optionally inserting them as new records.
+// Appends related to o.R.Actors.
+// Sets related.R.ActedMovies appropriately.
+func (o *Movie) AddActors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Person) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_actors\" (\"movie_id\", \"person_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &movieR{
+			Actors: related,
+		}
+	} else {
+		o.R.Actors = append(o.R.Actors, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &personR{
+				ActedMovies: MovieSlice{o},
+			}
+		} else {
+			rel.R.ActedMovies = append(rel.R.ActedMovies, o)
+		}
+	}
+	return nil
+}
+
+// SetActors removes all previously related items of the
+// movie replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.ActedMovies's Actors accordingly.
+// Replaces o.R.Actors with related.
+// Sets related.R.ActedMovies's Actors accordingly.
+func (o *Movie) SetActors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Person) error {
+	query := "delete from \"movie_actors\" where \"movie_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeActorsFromActedMoviesSlice(o, related)
+	if o.R != nil {
+		o.R.Actors = nil
+	}
+
+	return o.AddActors(ctx, exec, insert, related...)
+}
+
+// RemoveActors relationships from objects passed in.
+// Removes related items from R.Actors (uses pointer comparison, removal does not keep order)
+// Sets related.R.ActedMovies.
+func (o *Movie) RemoveActors(ctx context.Context, exec boil.ContextExecutor, related ...*Person) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_actors\" where \"movie_id\" = ? and \"person_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeActorsFromActedMoviesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Actors {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Actors)
+			if ln > 1 && i < ln-1 {
+				o.R.Actors[i] = o.R.Actors[ln-1]
+			}
+			o.R.Actors = o.R.Actors[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeActorsFromActedMoviesSlice(o *Movie, related []*Person) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.ActedMovies {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.ActedMovies)
+			if ln > 1 && i < ln-1 {
+				rel.R.ActedMovies[i] = rel.R.ActedMovies[ln-1]
+			}
+			rel.R.ActedMovies = rel.R.ActedMovies[:ln-1]
+			break
+		}
+	}
+}
+
+// AddCountries adds the given related objects to the existing relationships
+// of the movie, optionally inserting them as new records.
+// Appends related to o.R.Countries.
+// Sets related.R.Movies appropriately.
+func (o *Movie) AddCountries(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Country) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_countries\" (\"movie_id\", \"country_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &movieR{
+			Countries: related,
+		}
+	} else {
+		o.R.Countries = append(o.R.Countries, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &countryR{
+				Movies: MovieSlice{o},
+			}
+		} else {
+			rel.R.Movies = append(rel.R.Movies, o)
+		}
+	}
+	return nil
+}
+
+// SetCountries removes all previously related items of the
+// movie replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Movies's Countries accordingly.
+// Replaces o.R.Countries with related.
+// Sets related.R.Movies's Countries accordingly.
+func (o *Movie) SetCountries(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Country) error {
+	query := "delete from \"movie_countries\" where \"movie_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeCountriesFromMoviesSlice(o, related)
+	if o.R != nil {
+		o.R.Countries = nil
+	}
+
+	return o.AddCountries(ctx, exec, insert, related...)
+}
+
+// RemoveCountries relationships from objects passed in.
+// Removes related items from R.Countries (uses pointer comparison, removal does not keep order)
+// Sets related.R.Movies.
+func (o *Movie) RemoveCountries(ctx context.Context, exec boil.ContextExecutor, related ...*Country) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_countries\" where \"movie_id\" = ? and \"country_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeCountriesFromMoviesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Countries {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Countries)
+			if ln > 1 && i < ln-1 {
+				o.R.Countries[i] = o.R.Countries[ln-1]
+			}
+			o.R.Countries = o.R.Countries[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeCountriesFromMoviesSlice(o *Movie, related []*Country) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Movies {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Movies)
+			if ln > 1 && i < ln-1 {
+				rel.R.Movies[i] = rel.R.Movies[ln-1]
+			}
+			rel.R.Movies = rel.R.Movies[:ln-1]
+			break
+		}
+	}
+}
+
+// AddDirectors adds the given related objects to the existing relationships
+// of the movie, optionally inserting them as new records.
+// Appends related to o.R.Directors.
+// Sets related.R.DirectedMovies appropriately.
+func (o *Movie) AddDirectors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Person) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_directors\" (\"movie_id\", \"person_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &movieR{
+			Directors: related,
+		}
+	} else {
+		o.R.Directors = append(o.R.Directors, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &personR{
+				DirectedMovies: MovieSlice{o},
+			}
+		} else {
+			rel.R.DirectedMovies = append(rel.R.DirectedMovies, o)
+		}
+	}
+	return nil
+}
+
+// SetDirectors removes all previously related items of the
+// movie replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.DirectedMovies's Directors accordingly.
+// Replaces o.R.Directors with related.
+// Sets related.R.DirectedMovies's Directors accordingly.
+func (o *Movie) SetDirectors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Person) error {
+	query := "delete from \"movie_directors\" where \"movie_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeDirectorsFromDirectedMoviesSlice(o, related)
+	if o.R != nil {
+		o.R.Directors = nil
+	}
+
+	return o.AddDirectors(ctx, exec, insert, related...)
+}
+
+// RemoveDirectors relationships from objects passed in.
+// Removes related items from R.Directors (uses pointer comparison, removal does not keep order)
+// Sets related.R.DirectedMovies.
+func (o *Movie) RemoveDirectors(ctx context.Context, exec boil.ContextExecutor, related ...*Person) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_directors\" where \"movie_id\" = ? and \"person_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeDirectorsFromDirectedMoviesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Directors {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Directors)
+			if ln > 1 && i < ln-1 {
+				o.R.Directors[i] = o.R.Directors[ln-1]
+			}
+			o.R.Directors = o.R.Directors[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeDirectorsFromDirectedMoviesSlice(o *Movie, related []*Person) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.DirectedMovies {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.DirectedMovies)
+			if ln > 1 && i < ln-1 {
+				rel.R.DirectedMovies[i] = rel.R.DirectedMovies[ln-1]
+			}
+			rel.R.DirectedMovies = rel.R.DirectedMovies[:ln-1]
+			break
+		}
+	}
+}
+
+// AddGenres adds the given related objects to the existing relationships
+// of the movie, optionally inserting them as new records.
+// Appends related to o.R.Genres.
+// Sets related.R.Movies appropriately.
+func (o *Movie) AddGenres(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Genre) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_genres\" (\"movie_id\", \"genre_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &movieR{
+			Genres: related,
+		}
+	} else {
+		o.R.Genres = append(o.R.Genres, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &genreR{
+				Movies: MovieSlice{o},
+			}
+		} else {
+			rel.R.Movies = append(rel.R.Movies, o)
+		}
+	}
+	return nil
+}
+
+// SetGenres removes all previously related items of the
+// movie replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Movies's Genres accordingly.
+// Replaces o.R.Genres with related.
+// Sets related.R.Movies's Genres accordingly.
+func (o *Movie) SetGenres(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Genre) error {
+	query := "delete from \"movie_genres\" where \"movie_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeGenresFromMoviesSlice(o, related)
+	if o.R != nil {
+		o.R.Genres = nil
+	}
+
+	return o.AddGenres(ctx, exec, insert, related...)
+}
+
+// RemoveGenres relationships from objects passed in.
+// Removes related items from R.Genres (uses pointer comparison, removal does not keep order)
+// Sets related.R.Movies.
+func (o *Movie) RemoveGenres(ctx context.Context, exec boil.ContextExecutor, related ...*Genre) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_genres\" where \"movie_id\" = ? and \"genre_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeGenresFromMoviesSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Genres {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Genres)
+			if ln > 1 && i < ln-1 {
+				o.R.Genres[i] = o.R.Genres[ln-1]
+			}
+			o.R.Genres = o.R.Genres[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeGenresFromMoviesSlice(o *Movie, related []*Genre) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Movies {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Movies)
+			if ln > 1 && i < ln-1 {
+				rel.R.Movies[i] = rel.R.Movies[ln-1]
+			}
+			rel.R.Movies = rel.R.Movies[:ln-1]
+			break
+		}
+	}
+}
+
+// Movies retrieves all the records using an executor.
+func Movies(mods ...qm.QueryMod) movieQuery {
+	mods = append(mods, qm.From("\"movies\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"movies\".*"})
+	}
+
+	return movieQuery{q}
+}
+
+// FindMovie retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindMovie(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Movie, error) {
+	movieObj := &Movie{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"movies\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, movieObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from movies")
+	}
+
+	return movieObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Movie) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no movies provided for insertion")
+	}
+
+	var err error
+
+	nzDefaults := queries.NonZeroDefaultSet(movieColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	movieInsertCacheMut.RLock()
+	cache, cached := movieInsertCache[key]
+	movieInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			movieAllColumns,
+			movieColumnsWithDefault,
+			movieColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, movieGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(movieType, movieMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(movieType, movieMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"movies\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"movies\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into movies")
+	}
+
+	if !cached {
+		movieInsertCacheMut.Lock()
+		movieInsertCache[key] = cache
+		movieInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Movie.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Movie) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	movieUpdateCacheMut.RLock()
+	cache, cached := movieUpdateCache[key]
+	movieUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			movieAllColumns,
+			moviePrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, movieGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update movies, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"movies\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, moviePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(movieType, movieMapping, append(wl, moviePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update movies row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for movies")
+	}
+
+	if !cached {
+		movieUpdateCacheMut.Lock()
+		movieUpdateCache[key] = cache
+		movieUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q movieQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for movies")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o MovieSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), moviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"movies\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, moviePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in movie slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all movie")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Movie) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no movies provided for upsert")
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(movieColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	movieUpsertCacheMut.RLock()
+	cache, cached := movieUpsertCache[key]
+	movieUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			movieAllColumns,
+			movieColumnsWithDefault,
+			movieColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			movieAllColumns,
+			moviePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert movies, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(movieAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(moviePrimaryKeyColumns))
+			copy(conflict, moviePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"movies\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(movieType, movieMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(movieType, movieMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert movies")
+	}
+
+	if !cached {
+		movieUpsertCacheMut.Lock()
+		movieUpsertCache[key] = cache
+		movieUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Movie record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Movie) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Movie provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), moviePrimaryKeyMapping)
+	sql := "DELETE FROM \"movies\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for movies")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q movieQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no movieQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from movies")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for movies")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o MovieSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), moviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"movies\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, moviePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from movie slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for movies")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Movie) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindMovie(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *MovieSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := MovieSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), moviePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"movies\".* FROM \"movies\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, moviePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in MovieSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// MovieExists checks if the Movie row exists.
+func MovieExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"movies\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if movies exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Movie row exists.
+func (o *Movie) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return MovieExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1278 @@
+// Code generated by SQLBoiler 4.19.5 (https://github.com/aarondl/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/queries/qmhelper"
+	"github.com/aarondl/strmangle"
+	"github.com/friendsofgo/errors"
+)
+
+// Person is an object representing the database table.
+type Person struct {
+	ID   null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
+	Name string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+
+	R *personR `boil:"" json:"" toml:"" yaml:""`
+	L personL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PersonColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "id",
+	Name: "name",
+}
+
+var PersonTableColumns = struct {
+	ID   string
+	Name string
+}{
+	ID:   "people.id",
+	Name: "people.name",
+}
+
+// Generated where
+
+var PersonWhere = struct {
+	ID   whereHelpernull_Int64
+	Name whereHelperstring
+}{
+	ID:   whereHelpernull_Int64{field: "\"people\".\"id\""},
+	Name: whereHelperstring{field: "\"people\".\"name\""},
+}
+
+// PersonRels is where relationship names are stored.
+var PersonRels = struct {
+	ActedMovies    string
+	DirectedMovies string
+}{
+	ActedMovies:    "ActedMovies",
+	DirectedMovies: "DirectedMovies",
+}
+
+// personR is where relationships are stored.
+type personR struct {
+	ActedMovies    MovieSlice `boil:"ActedMovies" json:"ActedMovies" toml:"ActedMovies" yaml:"ActedMovies"`
+	DirectedMovies MovieSlice `boil:"DirectedMovies" json:"DirectedMovies" toml:"DirectedMovies" yaml:"DirectedMovies"`
+}
+
+// NewStruct creates a new relationship struct
+func (*personR) NewStruct() *personR {
+	return &personR{}
+}
+
+func (o *Person) GetActedMovies() MovieSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetActedMovies()
+}
+
+func (r *personR) GetActedMovies() MovieSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.ActedMovies
+}
+
+func (o *Person) GetDirectedMovies() MovieSlice {
+	if o == nil {
+		return nil
+	}
+
+	return o.R.GetDirectedMovies()
+}
+
+func (r *personR) GetDirectedMovies() MovieSlice {
+	if r == nil {
+		return nil
+	}
+
+	return r.DirectedMovies
+}
+
+// personL is where Load methods for each relationship are stored.
+type personL struct{}
+
+var (
+	personAllColumns            = []string{"id", "name"}
+	personColumnsWithoutDefault = []string{"name"}
+	personColumnsWithDefault    = []string{"id"}
+	personPrimaryKeyColumns     = []string{"id"}
+	personGeneratedColumns      = []string{"id"}
+)
+
+type (
+	// PersonSlice is an alias for a slice of pointers to Person.
+	// This should almost always be used instead of []Person.
+	PersonSlice []*Person
+
+	personQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	personType                 = reflect.TypeOf(&Person{})
+	personMapping              = queries.MakeStructMapping(personType)
+	personPrimaryKeyMapping, _ = queries.BindMapping(personType, personMapping, personPrimaryKeyColumns)
+	personInsertCacheMut       sync.RWMutex
+	personInsertCache          = make(map[string]insertCache)
+	personUpdateCacheMut       sync.RWMutex
+	personUpdateCache          = make(map[string]updateCache)
+	personUpsertCacheMut       sync.RWMutex
+	personUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single person record from the query.
+func (q personQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Person, error) {
+	o := &Person{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for people")
+	}
+
+	return o, nil
+}
+
+// All returns all Person records from the query.
+func (q personQuery) All(ctx context.Context, exec boil.ContextExecutor) (PersonSlice, error) {
+	var o []*Person
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Person slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Person records in the query.
+func (q personQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count people rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q personQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if people exists")
+	}
+
+	return count > 0, nil
+}
+
+// ActedMovies retrieves all the movie's Movies with an executor via id column.
+func (o *Person) ActedMovies(mods ...qm.QueryMod) movieQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_actors\" on \"movies\".\"id\" = \"movie_actors\".\"movie_id\""),
+		qm.Where("\"movie_actors\".\"person_id\"=?", o.ID),
+	)
+
+	return Movies(queryMods...)
+}
+
+// DirectedMovies retrieves all the movie's Movies with an executor via id column.
+func (o *Person) DirectedMovies(mods ...qm.QueryMod) movieQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"movie_directors\" on \"movies\".\"id\" = \"movie_directors\".\"movie_id\""),
+		qm.Where("\"movie_directors\".\"person_id\"=?", o.ID),
+	)
+
+	return Movies(queryMods...)
+}
+
+// LoadActedMovies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (personL) LoadActedMovies(ctx context.Context, e boil.ContextExecutor, singular bool, maybePerson interface{}, mods queries.Applicator) error {
+	var slice []*Person
+	var object *Person
+
+	if singular {
+		var ok bool
+		object, ok = maybePerson.(*Person)
+		if !ok {
+			object = new(Person)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePerson)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePerson))
+			}
+		}
+	} else {
+		s, ok := maybePerson.(*[]*Person)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePerson)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePerson))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &personR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &personR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"movies\".\"id\", \"movies\".\"title\", \"movies\".\"added_at\", \"movies\".\"rating\", \"a\".\"person_id\""),
+		qm.From("\"movies\""),
+		qm.InnerJoin("\"movie_actors\" as \"a\" on \"movies\".\"id\" = \"a\".\"movie_id\""),
+		qm.WhereIn("\"a\".\"person_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load movies")
+	}
+
+	var resultSlice []*Movie
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Movie)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Title, &one.AddedAt, &one.Rating, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for movies")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice movies")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on movies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for movies")
+	}
+
+	if singular {
+		object.R.ActedMovies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &movieR{}
+			}
+			foreign.R.Actors = append(foreign.R.Actors, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.ActedMovies = append(local.R.ActedMovies, foreign)
+				if foreign.R == nil {
+					foreign.R = &movieR{}
+				}
+				foreign.R.Actors = append(foreign.R.Actors, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadDirectedMovies allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (personL) LoadDirectedMovies(ctx context.Context, e boil.ContextExecutor, singular bool, maybePerson interface{}, mods queries.Applicator) error {
+	var slice []*Person
+	var object *Person
+
+	if singular {
+		var ok bool
+		object, ok = maybePerson.(*Person)
+		if !ok {
+			object = new(Person)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePerson)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePerson))
+			}
+		}
+	} else {
+		s, ok := maybePerson.(*[]*Person)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePerson)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePerson))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &personR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &personR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.Select("\"movies\".\"id\", \"movies\".\"title\", \"movies\".\"added_at\", \"movies\".\"rating\", \"a\".\"person_id\""),
+		qm.From("\"movies\""),
+		qm.InnerJoin("\"movie_directors\" as \"a\" on \"movies\".\"id\" = \"a\".\"movie_id\""),
+		qm.WhereIn("\"a\".\"person_id\" in ?", argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load movies")
+	}
+
+	var resultSlice []*Movie
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Movie)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Title, &one.AddedAt, &one.Rating, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for movies")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice movies")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on movies")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for movies")
+	}
+
+	if singular {
+		object.R.DirectedMovies = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &movieR{}
+			}
+			foreign.R.Directors = append(foreign.R.Directors, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.DirectedMovies = append(local.R.DirectedMovies, foreign)
+				if foreign.R == nil {
+					foreign.R = &movieR{}
+				}
+				foreign.R.Directors = append(foreign.R.Directors, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddActedMovies adds the given related objects to the existing relationships
+// of the person, optionally inserting them as new records.
+// Appends related to o.R.ActedMovies.
+// Sets related.R.Actors appropriately.
+func (o *Person) AddActedMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_actors\" (\"person_id\", \"movie_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &personR{
+			ActedMovies: related,
+		}
+	} else {
+		o.R.ActedMovies = append(o.R.ActedMovies, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &movieR{
+				Actors: PersonSlice{o},
+			}
+		} else {
+			rel.R.Actors = append(rel.R.Actors, o)
+		}
+	}
+	return nil
+}
+
+// SetActedMovies removes all previously related items of the
+// person replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Actors's ActedMovies accordingly.
+// Replaces o.R.ActedMovies with related.
+// Sets related.R.Actors's ActedMovies accordingly.
+func (o *Person) SetActedMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	query := "delete from \"movie_actors\" where \"person_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeActedMoviesFromActorsSlice(o, related)
+	if o.R != nil {
+		o.R.ActedMovies = nil
+	}
+
+	return o.AddActedMovies(ctx, exec, insert, related...)
+}
+
+// RemoveActedMovies relationships from objects passed in.
+// Removes related items from R.ActedMovies (uses pointer comparison, removal does not keep order)
+// Sets related.R.Actors.
+func (o *Person) RemoveActedMovies(ctx context.Context, exec boil.ContextExecutor, related ...*Movie) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_actors\" where \"person_id\" = ? and \"movie_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeActedMoviesFromActorsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ActedMovies {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ActedMovies)
+			if ln > 1 && i < ln-1 {
+				o.R.ActedMovies[i] = o.R.ActedMovies[ln-1]
+			}
+			o.R.ActedMovies = o.R.ActedMovies[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeActedMoviesFromActorsSlice(o *Person, related []*Movie) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Actors {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Actors)
+			if ln > 1 && i < ln-1 {
+				rel.R.Actors[i] = rel.R.Actors[ln-1]
+			}
+			rel.R.Actors = rel.R.Actors[:ln-1]
+			break
+		}
+	}
+}
+
+// AddDirectedMovies adds the given related objects to the existing relationships
+// of the person, optionally inserting them as new records.
+// Appends related to o.R.DirectedMovies.
+// Sets related.R.Directors appropriately.
+func (o *Person) AddDirectedMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"movie_directors\" (\"person_id\", \"movie_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, query)
+			fmt.Fprintln(writer, values)
+		}
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &personR{
+			DirectedMovies: related,
+		}
+	} else {
+		o.R.DirectedMovies = append(o.R.DirectedMovies, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &movieR{
+				Directors: PersonSlice{o},
+			}
+		} else {
+			rel.R.Directors = append(rel.R.Directors, o)
+		}
+	}
+	return nil
+}
+
+// SetDirectedMovies removes all previously related items of the
+// person replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Directors's DirectedMovies accordingly.
+// Replaces o.R.DirectedMovies with related.
+// Sets related.R.Directors's DirectedMovies accordingly.
+func (o *Person) SetDirectedMovies(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Movie) error {
+	query := "delete from \"movie_directors\" where \"person_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeDirectedMoviesFromDirectorsSlice(o, related)
+	if o.R != nil {
+		o.R.DirectedMovies = nil
+	}
+
+	return o.AddDirectedMovies(ctx, exec, insert, related...)
+}
+
+// RemoveDirectedMovies relationships from objects passed in.
+// Removes related items from R.DirectedMovies (uses pointer comparison, removal does not keep order)
+// Sets related.R.Directors.
+func (o *Person) RemoveDirectedMovies(ctx context.Context, exec boil.ContextExecutor, related ...*Movie) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"movie_directors\" where \"person_id\" = ? and \"movie_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeDirectedMoviesFromDirectorsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.DirectedMovies {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.DirectedMovies)
+			if ln > 1 && i < ln-1 {
+				o.R.DirectedMovies[i] = o.R.DirectedMovies[ln-1]
+			}
+			o.R.DirectedMovies = o.R.DirectedMovies[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeDirectedMoviesFromDirectorsSlice(o *Person, related []*Movie) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Directors {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Directors)
+			if ln > 1 && i < ln-1 {
+				rel.R.Directors[i] = rel.R.Directors[ln-1]
+			}
+			rel.R.Directors = rel.R.Directors[:ln-1]
+			break
+		}
+	}
+}
+
+// People retrieves all the records using an executor.
+func People(mods ...qm.QueryMod) personQuery {
+	mods = append(mods, qm.From("\"people\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"people\".*"})
+	}
+
+	return personQuery{q}
+}
+
+// FindPerson retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPerson(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Person, error) {
+	personObj := &Person{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"people\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, personObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from people")
+	}
+
+	return personObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Person) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no people provided for insertion")
+	}
+
+	var err error
+
+	nzDefaults := queries.NonZeroDefaultSet(personColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	personInsertCacheMut.RLock()
+	cache, cached := personInsertCache[key]
+	personInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			personAllColumns,
+			personColumnsWithDefault,
+			personColumnsWithoutDefault,
+			nzDefaults,
+		)
+		wl = strmangle.SetComplement(wl, personGeneratedColumns)
+
+		cache.valueMapping, err = queries.BindMapping(personType, personMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(personType, personMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"people\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"people\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into people")
+	}
+
+	if !cached {
+		personInsertCacheMut.Lock()
+		personInsertCache[key] = cache
+		personInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Person.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Person) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	personUpdateCacheMut.RLock()
+	cache, cached := personUpdateCache[key]
+	personUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			personAllColumns,
+			personPrimaryKeyColumns,
+		)
+		wl = strmangle.SetComplement(wl, personGeneratedColumns)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update people, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"people\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, personPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(personType, personMapping, append(wl, personPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update people row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for people")
+	}
+
+	if !cached {
+		personUpdateCacheMut.Lock()
+		personUpdateCache[key] = cache
+		personUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q personQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for people")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for people")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PersonSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), personPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"people\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, personPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in person slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all person")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Person) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no people provided for upsert")
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(personColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	personUpsertCacheMut.RLock()
+	cache, cached := personUpsertCache[key]
+	personUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			personAllColumns,
+			personColumnsWithDefault,
+			personColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			personAllColumns,
+			personPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert people, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(personAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(personPrimaryKeyColumns))
+			copy(conflict, personPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQuerySQLite(dialect, "\"people\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(personType, personMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(personType, personMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert people")
+	}
+
+	if !cached {
+		personUpsertCacheMut.Lock()
+		personUpsertCache[key] = cache
+		personUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Person record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Person) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Person provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), personPrimaryKeyMapping)
+	sql := "DELETE FROM \"people\" WHERE \"id\"=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from people")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for people")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q personQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no personQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from people")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for people")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PersonSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), personPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"people\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, personPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from person slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for people")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Person) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPerson(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PersonSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PersonSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), personPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"people\".* FROM \"people\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, personPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PersonSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PersonExists checks if the Person row exists.
+func PersonExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"people\" where \"id\"=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if people exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Person row exists.
+func (o *Person) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return PersonExists(ctx, exec, o.ID)
+}
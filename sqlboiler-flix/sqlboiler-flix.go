@@ -0,0 +1,366 @@
+package sqlboilerflix
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+	"github.com/wroge/bench-flix/sqlboiler-flix/internal/models"
+	sqlcflix "github.com/wroge/bench-flix/sqlc-flix"
+)
+
+//go:generate go run generate.go
+
+func init() {
+	drivers.Register("sqlboiler", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = db.Exec(sqlcflix.Schema); err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: db,
+	}, nil
+}
+
+type Repository struct {
+	DB *sql.DB
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	affected, err := (&models.Movie{ID: null.Int64From(id)}).Delete(ctx, r.DB)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Insert leaves out the INTEGER PRIMARY KEY, which sqlboiler treats as
+	// generated, so the movie is inserted with its ID by hand.
+	_, err = queries.Raw(
+		`INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?)`,
+		movie.ID, movie.Title, movie.AddedAt, movie.Rating,
+	).ExecContext(ctx, tx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return setRelations(ctx, tx, movie, false)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	affected, err := toModel(movie).Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return setRelations(ctx, tx, movie, true)
+}
+
+// setRelations upserts the names of movie and links them. If replace is set,
+// the existing links are removed first.
+func setRelations(ctx context.Context, tx *sql.Tx, movie benchflix.Movie, replace bool) error {
+	model := toModel(movie)
+
+	directors, err := upsert(ctx, tx, movie.Directors, func(name string) *models.Person { return &models.Person{Name: name} })
+	if err != nil {
+		return err
+	}
+
+	actors, err := upsert(ctx, tx, movie.Actors, func(name string) *models.Person { return &models.Person{Name: name} })
+	if err != nil {
+		return err
+	}
+
+	countries, err := upsert(ctx, tx, movie.Countries, func(name string) *models.Country { return &models.Country{Name: name} })
+	if err != nil {
+		return err
+	}
+
+	genres, err := upsert(ctx, tx, movie.Genres, func(name string) *models.Genre { return &models.Genre{Name: name} })
+	if err != nil {
+		return err
+	}
+
+	if replace {
+		if err = model.SetDirectors(ctx, tx, false, directors...); err != nil {
+			return err
+		}
+
+		if err = model.SetActors(ctx, tx, false, actors...); err != nil {
+			return err
+		}
+
+		if err = model.SetCountries(ctx, tx, false, countries...); err != nil {
+			return err
+		}
+
+		return model.SetGenres(ctx, tx, false, genres...)
+	}
+
+	if err = model.AddDirectors(ctx, tx, false, directors...); err != nil {
+		return err
+	}
+
+	if err = model.AddActors(ctx, tx, false, actors...); err != nil {
+		return err
+	}
+
+	if err = model.AddCountries(ctx, tx, false, countries...); err != nil {
+		return err
+	}
+
+	return model.AddGenres(ctx, tx, false, genres...)
+}
+
+// upsert inserts a row for every name, or keeps the existing one, and returns
+// the rows with their IDs.
+func upsert[T interface {
+	Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error
+}](ctx context.Context, tx *sql.Tx, names []string, row func(name string) T) ([]T, error) {
+	rows := make([]T, len(names))
+
+	for i, name := range names {
+		rows[i] = row(name)
+
+		if err := rows[i].Upsert(ctx, tx, true, []string{"name"}, boil.Whitelist("name"), boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+// filter returns the query mods of the conditions of query.
+func filter(query benchflix.Query) []qm.QueryMod {
+	var mods []qm.QueryMod
+
+	if query.Search != "" {
+		mods = append(mods, qm.Where(`EXISTS (
+				SELECT 1 FROM movie_directors
+				JOIN people ON people.id = movie_directors.person_id
+				WHERE movie_directors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)
+			OR EXISTS (
+				SELECT 1 FROM movie_actors
+				JOIN people ON people.id = movie_actors.person_id
+				WHERE movie_actors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)`, query.Search, query.Search))
+	}
+
+	if query.Genre != "" {
+		mods = append(mods, qm.Where(`EXISTS (
+			SELECT 1 FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id AND genres.name = ?
+		)`, query.Genre))
+	}
+
+	if query.Country != "" {
+		mods = append(mods, qm.Where(`EXISTS (
+			SELECT 1 FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id AND countries.name = ?
+		)`, query.Country))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		mods = append(mods, models.MovieWhere.AddedAt.LT(query.AddedBefore))
+	}
+
+	if !query.AddedAfter.IsZero() {
+		mods = append(mods, models.MovieWhere.AddedAt.GT(query.AddedAfter))
+	}
+
+	if query.MinRating > 0 {
+		mods = append(mods, models.MovieWhere.Rating.GTE(query.MinRating))
+	}
+
+	if query.MaxRating > 0 {
+		mods = append(mods, models.MovieWhere.Rating.LTE(query.MaxRating))
+	}
+
+	return mods
+}
+
+// load eager loads the four many-to-many relations of movies, ordered by name,
+// with one statement per relation.
+func load(ctx context.Context, exec boil.ContextExecutor, movies models.MovieSlice) error {
+	l, slice := models.Movie{}.L, []*models.Movie(movies)
+
+	if err := l.LoadDirectors(ctx, exec, false, &slice, qm.OrderBy(models.PersonTableColumns.Name)); err != nil {
+		return err
+	}
+
+	if err := l.LoadActors(ctx, exec, false, &slice, qm.OrderBy(models.PersonTableColumns.Name)); err != nil {
+		return err
+	}
+
+	if err := l.LoadCountries(ctx, exec, false, &slice, qm.OrderBy(models.CountryTableColumns.Name)); err != nil {
+		return err
+	}
+
+	return l.LoadGenres(ctx, exec, false, &slice, qm.OrderBy(models.GenreTableColumns.Name))
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	mods := filter(query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		mods = append(mods, qm.Where(
+			fmt.Sprintf("(movies.%s, movies.id) %s (?, ?)", column, operator),
+			query.After.Value(query.Sort.Field), query.After.ID,
+		))
+	}
+
+	mods = append(mods, qm.OrderBy(fmt.Sprintf("movies.%s %s, movies.id %s", column, query.Sort.Direction(), query.Sort.Direction())))
+
+	if query.Limit > 0 {
+		mods = append(mods, qm.Limit(int(query.Limit)))
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT.
+			mods = append(mods, qm.Limit(-1))
+		}
+
+		mods = append(mods, qm.Offset(int(query.Offset)))
+	}
+
+	movies, err := models.Movies(mods...).All(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = load(ctx, r.DB, movies); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i] = fromModel(movie)
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	movie, err := models.Movies(models.MovieWhere.ID.EQ(null.Int64From(id))).One(ctx, r.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	if err = load(ctx, r.DB, models.MovieSlice{movie}); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return fromModel(movie), nil
+}
+
+// toModel converts movie to its model. The IDs are null.Int64, because INTEGER
+// PRIMARY KEY columns are not declared NOT NULL in the schema.
+func toModel(movie benchflix.Movie) *models.Movie {
+	return &models.Movie{
+		ID:      null.Int64From(movie.ID),
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+}
+
+func fromModel(movie *models.Movie) benchflix.Movie {
+	result := benchflix.Movie{
+		ID:      movie.ID.Int64,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+
+	for _, person := range movie.R.GetDirectors() {
+		result.Directors = append(result.Directors, person.Name)
+	}
+
+	for _, person := range movie.R.GetActors() {
+		result.Actors = append(result.Actors, person.Name)
+	}
+
+	for _, country := range movie.R.GetCountries() {
+		result.Countries = append(result.Countries, country.Name)
+	}
+
+	for _, genre := range movie.R.GetGenres() {
+		result.Genres = append(result.Genres, genre.Name)
+	}
+
+	return result
+}
@@ -70,6 +70,245 @@ func (q *Queries) AddMovieGenre(ctx context.Context, arg AddMovieGenreParams) er
 	return err
 }
 
+const countCountries = `-- name: CountCountries :many
+SELECT countries.name, COUNT(*) AS count
+FROM movie_countries
+JOIN countries ON countries.id = movie_countries.country_id
+JOIN movies ON movies.id = movie_countries.movie_id
+WHERE
+    (?1 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    ))
+    AND (?2 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = ?2
+    ))
+    AND (?3 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = ?3
+    ))
+    AND (?4 IS NULL OR movies.added_at > ?4)
+    AND (?5 IS NULL OR movies.added_at < ?5)
+    AND (?6 <= 0 OR movies.rating >= ?6)
+    AND (?7 <= 0 OR movies.rating <= ?7)
+GROUP BY countries.name
+ORDER BY COUNT(*) DESC, countries.name ASC
+`
+
+type CountCountriesParams struct {
+	Search      interface{}
+	Genre       interface{}
+	Country     interface{}
+	AddedAfter  interface{}
+	AddedBefore interface{}
+	MinRating   interface{}
+	MaxRating   interface{}
+}
+
+type CountCountriesRow struct {
+	Name  string
+	Count int64
+}
+
+func (q *Queries) CountCountries(ctx context.Context, arg CountCountriesParams) ([]CountCountriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, countCountries,
+		arg.Search,
+		arg.Genre,
+		arg.Country,
+		arg.AddedAfter,
+		arg.AddedBefore,
+		arg.MinRating,
+		arg.MaxRating,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountCountriesRow
+	for rows.Next() {
+		var i CountCountriesRow
+		if err := rows.Scan(&i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countGenres = `-- name: CountGenres :many
+SELECT genres.name, COUNT(*) AS count
+FROM movie_genres
+JOIN genres ON genres.id = movie_genres.genre_id
+JOIN movies ON movies.id = movie_genres.movie_id
+WHERE
+    (?1 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    ))
+    AND (?2 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = ?2
+    ))
+    AND (?3 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = ?3
+    ))
+    AND (?4 IS NULL OR movies.added_at > ?4)
+    AND (?5 IS NULL OR movies.added_at < ?5)
+    AND (?6 <= 0 OR movies.rating >= ?6)
+    AND (?7 <= 0 OR movies.rating <= ?7)
+GROUP BY genres.name
+ORDER BY COUNT(*) DESC, genres.name ASC
+`
+
+type CountGenresParams struct {
+	Search      interface{}
+	Genre       interface{}
+	Country     interface{}
+	AddedAfter  interface{}
+	AddedBefore interface{}
+	MinRating   interface{}
+	MaxRating   interface{}
+}
+
+type CountGenresRow struct {
+	Name  string
+	Count int64
+}
+
+func (q *Queries) CountGenres(ctx context.Context, arg CountGenresParams) ([]CountGenresRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGenres,
+		arg.Search,
+		arg.Genre,
+		arg.Country,
+		arg.AddedAfter,
+		arg.AddedBefore,
+		arg.MinRating,
+		arg.MaxRating,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGenresRow
+	for rows.Next() {
+		var i CountGenresRow
+		if err := rows.Scan(&i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countMovies = `-- name: CountMovies :one
+SELECT COUNT(*)
+FROM movies
+WHERE
+    (?1 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    ))
+    AND (?2 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = ?2
+    ))
+    AND (?3 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = ?3
+    ))
+    AND (?4 IS NULL OR movies.added_at > ?4)
+    AND (?5 IS NULL OR movies.added_at < ?5)
+    AND (?6 <= 0 OR movies.rating >= ?6)
+    AND (?7 <= 0 OR movies.rating <= ?7)
+`
+
+type CountMoviesParams struct {
+	Search      interface{}
+	Genre       interface{}
+	Country     interface{}
+	AddedAfter  interface{}
+	AddedBefore interface{}
+	MinRating   interface{}
+	MaxRating   interface{}
+}
+
+func (q *Queries) CountMovies(ctx context.Context, arg CountMoviesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMovies,
+		arg.Search,
+		arg.Genre,
+		arg.Country,
+		arg.AddedAfter,
+		arg.AddedBefore,
+		arg.MinRating,
+		arg.MaxRating,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createMovie = `-- name: CreateMovie :one
 INSERT INTO movies (id, title, added_at, rating)
 VALUES (?, ?, ?, ?)
@@ -95,12 +334,51 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (int64
 	return id, err
 }
 
-const deleteMovie = `-- name: DeleteMovie :exec
+const deleteMovie = `-- name: DeleteMovie :execrows
 DELETE FROM movies WHERE id = ?
 `
 
-func (q *Queries) DeleteMovie(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteMovie, id)
+func (q *Queries) DeleteMovie(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMovie, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMovieActors = `-- name: DeleteMovieActors :exec
+DELETE FROM movie_actors WHERE movie_id = ?
+`
+
+func (q *Queries) DeleteMovieActors(ctx context.Context, movieID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMovieActors, movieID)
+	return err
+}
+
+const deleteMovieCountries = `-- name: DeleteMovieCountries :exec
+DELETE FROM movie_countries WHERE movie_id = ?
+`
+
+func (q *Queries) DeleteMovieCountries(ctx context.Context, movieID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMovieCountries, movieID)
+	return err
+}
+
+const deleteMovieDirectors = `-- name: DeleteMovieDirectors :exec
+DELETE FROM movie_directors WHERE movie_id = ?
+`
+
+func (q *Queries) DeleteMovieDirectors(ctx context.Context, movieID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMovieDirectors, movieID)
+	return err
+}
+
+const deleteMovieGenres = `-- name: DeleteMovieGenres :exec
+DELETE FROM movie_genres WHERE movie_id = ?
+`
+
+func (q *Queries) DeleteMovieGenres(ctx context.Context, movieID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMovieGenres, movieID)
 	return err
 }
 
@@ -110,8 +388,8 @@ SELECT
     movies.title,
     movies.added_at,
     movies.rating,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_directors
@@ -119,9 +397,9 @@ SELECT
             WHERE movie_directors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS directors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_actors
@@ -129,9 +407,9 @@ SELECT
             WHERE movie_actors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS actors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT countries.name
             FROM movie_countries
@@ -139,9 +417,9 @@ SELECT
             WHERE movie_countries.movie_id = movies.id
             ORDER BY countries.name ASC
         )
-    ), '') AS TEXT) AS countries,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT genres.name
             FROM movie_genres
@@ -149,7 +427,7 @@ SELECT
             WHERE movie_genres.movie_id = movies.id
             ORDER BY genres.name
         )
-    ), '') AS TEXT) AS genres
+    ) AS TEXT) AS genres
 FROM movies
 WHERE movies.id = ?
 `
@@ -229,8 +507,8 @@ SELECT
     movies.title,
     movies.added_at,
     movies.rating,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_directors
@@ -238,9 +516,9 @@ SELECT
             WHERE movie_directors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS directors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_actors
@@ -248,9 +526,9 @@ SELECT
             WHERE movie_actors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS actors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT countries.name
             FROM movie_countries
@@ -258,9 +536,9 @@ SELECT
             WHERE movie_countries.movie_id = movies.id
             ORDER BY countries.name ASC
         )
-    ), '') AS TEXT) AS countries,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT genres.name
             FROM movie_genres
@@ -268,7 +546,7 @@ SELECT
             WHERE movie_genres.movie_id = movies.id
             ORDER BY genres.name
         )
-    ), '') AS TEXT) AS genres
+    ) AS TEXT) AS genres
 FROM movies
 WHERE
     (?1 = '' OR EXISTS (
@@ -276,14 +554,14 @@ WHERE
         FROM movie_directors
         JOIN people ON people.id = movie_directors.person_id
         WHERE movie_directors.movie_id = movies.id
-        AND INSTR(LOWER(people.name), LOWER(?1)) > 0
+        AND INSTR(people.name, ?1) > 0
     )
     OR EXISTS (
         SELECT 1
         FROM movie_actors
         JOIN people ON people.id = movie_actors.person_id
         WHERE movie_actors.movie_id = movies.id
-        AND INSTR(LOWER(people.name), LOWER(?1)) > 0
+        AND INSTR(people.name, ?1) > 0
     ))
     AND (?2 = '' OR EXISTS (
         SELECT 1
@@ -299,12 +577,53 @@ WHERE
         WHERE movie_countries.movie_id = movies.id
         AND countries.name = ?3
     ))
-    AND (?4 IS NULL OR movies.added_at >= ?4)
-    AND (?5 IS NULL OR movies.added_at <= ?5)
+    AND (?4 IS NULL OR movies.added_at > ?4)
+    AND (?5 IS NULL OR movies.added_at < ?5)
     AND (?6 <= 0 OR movies.rating >= ?6)
     AND (?7 <= 0 OR movies.rating <= ?7)
-ORDER BY movies.title ASC
-LIMIT CASE WHEN ?8 > 0 THEN ?8 ELSE -1 END
+    AND (?8 IS NULL OR CASE WHEN ?9
+        THEN CASE ?10
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END < ?11
+            OR (CASE ?10
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = ?11 AND movies.id < ?8)
+        ELSE CASE ?10
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END > ?11
+            OR (CASE ?10
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = ?11 AND movies.id > ?8)
+    END)
+ORDER BY
+    CASE WHEN ?9 THEN CASE ?10
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END END DESC,
+    CASE WHEN ?9 THEN movies.id END DESC,
+    CASE ?10
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END ASC,
+    movies.id ASC
+LIMIT CASE WHEN ?12 > 0 THEN ?12 ELSE -1 END
+OFFSET ?13
 `
 
 type QueryMoviesParams struct {
@@ -315,7 +634,12 @@ type QueryMoviesParams struct {
 	AddedBefore interface{}
 	MinRating   interface{}
 	MaxRating   interface{}
+	AfterID     interface{}
+	Descending  interface{}
+	Sort        interface{}
+	AfterValue  interface{}
 	Limit       interface{}
+	Offset      interface{}
 }
 
 type QueryMoviesRow struct {
@@ -338,7 +662,12 @@ func (q *Queries) QueryMovies(ctx context.Context, arg QueryMoviesParams) ([]Que
 		arg.AddedBefore,
 		arg.MinRating,
 		arg.MaxRating,
+		arg.AfterID,
+		arg.Descending,
+		arg.Sort,
+		arg.AfterValue,
 		arg.Limit,
+		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
@@ -369,3 +698,28 @@ func (q *Queries) QueryMovies(ctx context.Context, arg QueryMoviesParams) ([]Que
 	}
 	return items, nil
 }
+
+const updateMovie = `-- name: UpdateMovie :execrows
+UPDATE movies SET title = ?, added_at = ?, rating = ?
+WHERE id = ?
+`
+
+type UpdateMovieParams struct {
+	Title   string
+	AddedAt time.Time
+	Rating  float64
+	ID      int64
+}
+
+func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateMovie,
+		arg.Title,
+		arg.AddedAt,
+		arg.Rating,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// StreamMovies runs the QueryMovies query and yields the rows while scanning
+// them. sqlc only generates slice returning methods, so this one is maintained
+// by hand next to the generated code.
+func (q *Queries) StreamMovies(ctx context.Context, arg QueryMoviesParams) iter.Seq2[QueryMoviesRow, error] {
+	return func(yield func(QueryMoviesRow, error) bool) {
+		rows, err := q.db.QueryContext(ctx, queryMovies,
+			arg.Search,
+			arg.Genre,
+			arg.Country,
+			arg.AddedAfter,
+			arg.AddedBefore,
+			arg.MinRating,
+			arg.MaxRating,
+			arg.AfterID,
+			arg.Descending,
+			arg.Sort,
+			arg.AfterValue,
+			arg.Limit,
+			arg.Offset,
+		)
+		if err != nil {
+			yield(QueryMoviesRow{}, err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var i QueryMoviesRow
+
+			if err := rows.Scan(
+				&i.ID,
+				&i.Title,
+				&i.AddedAt,
+				&i.Rating,
+				&i.Directors,
+				&i.Actors,
+				&i.Countries,
+				&i.Genres,
+			); err != nil {
+				yield(QueryMoviesRow{}, err)
+
+				return
+			}
+
+			if !yield(i, nil) {
+				return
+			}
+		}
+
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			yield(QueryMoviesRow{}, err)
+		}
+	}
+}
@@ -37,17 +37,33 @@ VALUES (?, ?);
 INSERT OR IGNORE INTO movie_genres (movie_id, genre_id)
 VALUES (?, ?);
 
--- name: DeleteMovie :exec
+-- name: DeleteMovie :execrows
 DELETE FROM movies WHERE id = ?;
 
+-- name: UpdateMovie :execrows
+UPDATE movies SET title = ?, added_at = ?, rating = ?
+WHERE id = ?;
+
+-- name: DeleteMovieDirectors :exec
+DELETE FROM movie_directors WHERE movie_id = ?;
+
+-- name: DeleteMovieActors :exec
+DELETE FROM movie_actors WHERE movie_id = ?;
+
+-- name: DeleteMovieCountries :exec
+DELETE FROM movie_countries WHERE movie_id = ?;
+
+-- name: DeleteMovieGenres :exec
+DELETE FROM movie_genres WHERE movie_id = ?;
+
 -- name: GetMovie :one
 SELECT
     movies.id,
     movies.title,
     movies.added_at,
     movies.rating,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_directors
@@ -55,9 +71,9 @@ SELECT
             WHERE movie_directors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS directors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_actors
@@ -65,9 +81,9 @@ SELECT
             WHERE movie_actors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS actors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT countries.name
             FROM movie_countries
@@ -75,9 +91,9 @@ SELECT
             WHERE movie_countries.movie_id = movies.id
             ORDER BY countries.name ASC
         )
-    ), '') AS TEXT) AS countries,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT genres.name
             FROM movie_genres
@@ -85,7 +101,7 @@ SELECT
             WHERE movie_genres.movie_id = movies.id
             ORDER BY genres.name
         )
-    ), '') AS TEXT) AS genres
+    ) AS TEXT) AS genres
 FROM movies
 WHERE movies.id = ?;
 
@@ -95,8 +111,8 @@ SELECT
     movies.title,
     movies.added_at,
     movies.rating,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_directors
@@ -104,9 +120,9 @@ SELECT
             WHERE movie_directors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS directors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT people.name
             FROM movie_actors
@@ -114,9 +130,9 @@ SELECT
             WHERE movie_actors.movie_id = movies.id
             ORDER BY people.name ASC
         )
-    ), '') AS TEXT) AS actors,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT countries.name
             FROM movie_countries
@@ -124,9 +140,9 @@ SELECT
             WHERE movie_countries.movie_id = movies.id
             ORDER BY countries.name ASC
         )
-    ), '') AS TEXT) AS countries,
-    CAST(IFNULL((
-        SELECT GROUP_CONCAT(name)
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
         FROM (
             SELECT genres.name
             FROM movie_genres
@@ -134,7 +150,87 @@ SELECT
             WHERE movie_genres.movie_id = movies.id
             ORDER BY genres.name
         )
-    ), '') AS TEXT) AS genres
+    ) AS TEXT) AS genres
+FROM movies
+WHERE
+    (:search = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
+    ))
+    AND (:genre = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = :genre
+    ))
+    AND (:country = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = :country
+    ))
+    AND (:added_after IS NULL OR movies.added_at > :added_after)
+    AND (:added_before IS NULL OR movies.added_at < :added_before)
+    AND (:min_rating <= 0 OR movies.rating >= :min_rating)
+    AND (:max_rating <= 0 OR movies.rating <= :max_rating)
+    AND (:after_id IS NULL OR CASE WHEN :descending
+        THEN CASE :sort
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END < :after_value
+            OR (CASE :sort
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = :after_value AND movies.id < :after_id)
+        ELSE CASE :sort
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END > :after_value
+            OR (CASE :sort
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = :after_value AND movies.id > :after_id)
+    END)
+ORDER BY
+    CASE WHEN :descending THEN CASE :sort
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END END DESC,
+    CASE WHEN :descending THEN movies.id END DESC,
+    CASE :sort
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END ASC,
+    movies.id ASC
+LIMIT CASE WHEN :limit > 0 THEN :limit ELSE -1 END
+OFFSET :offset;
+
+-- name: CountMovies :one
+SELECT COUNT(*)
 FROM movies
 WHERE
     (:search = '' OR EXISTS (
@@ -142,14 +238,94 @@ WHERE
         FROM movie_directors
         JOIN people ON people.id = movie_directors.person_id
         WHERE movie_directors.movie_id = movies.id
-        AND INSTR(LOWER(people.name), LOWER(:search)) > 0
+        AND INSTR(people.name, :search) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
+    ))
+    AND (:genre = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = :genre
+    ))
+    AND (:country = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = :country
+    ))
+    AND (:added_after IS NULL OR movies.added_at > :added_after)
+    AND (:added_before IS NULL OR movies.added_at < :added_before)
+    AND (:min_rating <= 0 OR movies.rating >= :min_rating)
+    AND (:max_rating <= 0 OR movies.rating <= :max_rating);
+
+-- name: CountGenres :many
+SELECT genres.name, COUNT(*) AS count
+FROM movie_genres
+JOIN genres ON genres.id = movie_genres.genre_id
+JOIN movies ON movies.id = movie_genres.movie_id
+WHERE
+    (:search = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
+    ))
+    AND (:genre = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = :genre
+    ))
+    AND (:country = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = :country
+    ))
+    AND (:added_after IS NULL OR movies.added_at > :added_after)
+    AND (:added_before IS NULL OR movies.added_at < :added_before)
+    AND (:min_rating <= 0 OR movies.rating >= :min_rating)
+    AND (:max_rating <= 0 OR movies.rating <= :max_rating)
+GROUP BY genres.name
+ORDER BY COUNT(*) DESC, genres.name ASC;
+
+-- name: CountCountries :many
+SELECT countries.name, COUNT(*) AS count
+FROM movie_countries
+JOIN countries ON countries.id = movie_countries.country_id
+JOIN movies ON movies.id = movie_countries.movie_id
+WHERE
+    (:search = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, :search) > 0
     )
     OR EXISTS (
         SELECT 1
         FROM movie_actors
         JOIN people ON people.id = movie_actors.person_id
         WHERE movie_actors.movie_id = movies.id
-        AND INSTR(LOWER(people.name), LOWER(:search)) > 0
+        AND INSTR(people.name, :search) > 0
     ))
     AND (:genre = '' OR EXISTS (
         SELECT 1
@@ -165,9 +341,9 @@ WHERE
         WHERE movie_countries.movie_id = movies.id
         AND countries.name = :country
     ))
-    AND (:added_after IS NULL OR movies.added_at >= :added_after)
-    AND (:added_before IS NULL OR movies.added_at <= :added_before)
+    AND (:added_after IS NULL OR movies.added_at > :added_after)
+    AND (:added_before IS NULL OR movies.added_at < :added_before)
     AND (:min_rating <= 0 OR movies.rating >= :min_rating)
     AND (:max_rating <= 0 OR movies.rating <= :max_rating)
-ORDER BY movies.title ASC
-LIMIT CASE WHEN :limit > 0 THEN :limit ELSE -1 END;
\ No newline at end of file
+GROUP BY countries.name
+ORDER BY COUNT(*) DESC, countries.name ASC;
\ No newline at end of file
@@ -4,31 +4,40 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"iter"
 
-	_ "github.com/mattn/go-sqlite3"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 	"github.com/wroge/bench-flix/sqlc-flix/internal/db"
 )
 
+// Schema creates the tables that sqlc generates the queries for. sqlboiler-flix
+// and jet-flix generate their models from it and create their tables with it,
+// too.
+//
 //go:embed schema.sql
-var ddl string
+var Schema string
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
+func init() {
+	drivers.Register("sqlc", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
 	sqldb, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	if _, err := sqldb.Exec(ddl); err != nil {
-		panic(err)
+	if _, err := sqldb.Exec(Schema); err != nil {
+		return nil, err
 	}
 
 	return Repository{
 		DB: sqldb,
-	}
+	}, nil
 }
 
 type Repository struct {
@@ -36,7 +45,16 @@ type Repository struct {
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	return db.New(r.DB).DeleteMovie(ctx, id)
+	affected, err := db.New(r.DB).DeleteMovie(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
@@ -61,9 +79,104 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 		AddedAt: movie.AddedAt,
 		Rating:  movie.Rating,
 	}); err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return addRelations(ctx, txdb, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	txdb := db.New(tx)
+
+	affected, err := txdb.UpdateMovie(ctx, db.UpdateMovieParams{
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+		ID:      movie.ID,
+	})
+	if err != nil {
 		return err
 	}
 
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	if err = txdb.DeleteMovieDirectors(ctx, movie.ID); err != nil {
+		return err
+	}
+
+	if err = txdb.DeleteMovieActors(ctx, movie.ID); err != nil {
+		return err
+	}
+
+	if err = txdb.DeleteMovieCountries(ctx, movie.ID); err != nil {
+		return err
+	}
+
+	if err = txdb.DeleteMovieGenres(ctx, movie.ID); err != nil {
+		return err
+	}
+
+	return addRelations(ctx, txdb, movie)
+}
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	txdb := db.New(tx)
+
+	for _, movie := range movies {
+		if _, err = txdb.CreateMovie(ctx, db.CreateMovieParams{
+			ID:      movie.ID,
+			Title:   movie.Title,
+			AddedAt: movie.AddedAt,
+			Rating:  movie.Rating,
+		}); err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+
+		if err = addRelations(ctx, txdb, movie); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addRelations(ctx context.Context, txdb *db.Queries, movie benchflix.Movie) error {
 	for _, name := range movie.Directors {
 		id, err := txdb.GetOrCreatePerson(ctx, name)
 		if err != nil {
@@ -126,22 +239,74 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
 	row, err := db.New(r.DB).GetMovie(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
-	return benchflix.Movie{
-		ID:        row.ID,
-		Title:     row.Title,
-		AddedAt:   row.AddedAt,
-		Rating:    row.Rating,
-		Directors: splitCSV(row.Directors),
-		Actors:    splitCSV(row.Actors),
-		Countries: splitCSV(row.Countries),
-		Genres:    splitCSV(row.Genres),
-	}, nil
+	return convertRow(db.QueryMoviesRow(row))
 }
 
 func (r Repository) Query(ctx context.Context, q benchflix.Query) ([]benchflix.Movie, error) {
+	params, err := queryParams(q)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.New(r.DB).QueryMovies(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("HERE: %w", err)
+	}
+
+	movies := make([]benchflix.Movie, len(rows))
+	for i, row := range rows {
+		movies[i], err = convertRow(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return movies, nil
+}
+
+func (r Repository) Stream(ctx context.Context, q benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		params, err := queryParams(q)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		for row, err := range db.New(r.DB).StreamMovies(ctx, params) {
+			if err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			movie, err := convertRow(row)
+			if err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			if !yield(movie, nil) {
+				return
+			}
+		}
+	}
+}
+
+func queryParams(q benchflix.Query) (db.QueryMoviesParams, error) {
+	column, err := q.Sort.Column()
+	if err != nil {
+		return db.QueryMoviesParams{}, err
+	}
+
 	params := db.QueryMoviesParams{
 		Search:      q.Search,
 		Genre:       q.Genre,
@@ -150,35 +315,107 @@ func (r Repository) Query(ctx context.Context, q benchflix.Query) ([]benchflix.M
 		AddedBefore: sql.NullTime{Time: q.AddedBefore, Valid: !q.AddedBefore.IsZero()},
 		MinRating:   q.MinRating,
 		MaxRating:   q.MaxRating,
+		Sort:        column,
+		Descending:  q.Sort.Descending,
 		Limit:       q.Limit,
+		Offset:      q.Offset,
 	}
 
-	rows, err := db.New(r.DB).QueryMovies(ctx, params)
-	if err != nil {
-		return nil, fmt.Errorf("HERE: %w", err)
+	if q.After != nil {
+		params.AfterID = q.After.ID
+		params.AfterValue = q.After.Value(q.Sort.Field)
 	}
 
-	movies := make([]benchflix.Movie, len(rows))
-	for i, row := range rows {
-		movies[i] = benchflix.Movie{
-			ID:        row.ID,
-			Title:     row.Title,
-			AddedAt:   row.AddedAt,
-			Rating:    row.Rating,
-			Directors: splitCSV(row.Directors),
-			Actors:    splitCSV(row.Actors),
-			Countries: splitCSV(row.Countries),
-			Genres:    splitCSV(row.Genres),
-		}
+	return params, nil
+}
+
+// convertRow decodes the names aggregated with json_group_array.
+func convertRow(row db.QueryMoviesRow) (benchflix.Movie, error) {
+	movie := benchflix.Movie{
+		ID:      row.ID,
+		Title:   row.Title,
+		AddedAt: row.AddedAt,
+		Rating:  row.Rating,
 	}
 
-	return movies, nil
+	if err := json.Unmarshal([]byte(row.Directors), &movie.Directors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal([]byte(row.Actors), &movie.Actors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal([]byte(row.Countries), &movie.Countries); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal([]byte(row.Genres), &movie.Genres); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return movie, nil
 }
 
-func splitCSV(s string) []string {
-	if s == "" {
-		return nil
+func (r Repository) Facets(ctx context.Context, q benchflix.Query) (benchflix.Facets, error) {
+	var (
+		queries     = db.New(r.DB)
+		addedAfter  = sql.NullTime{Time: q.AddedAfter, Valid: !q.AddedAfter.IsZero()}
+		addedBefore = sql.NullTime{Time: q.AddedBefore, Valid: !q.AddedBefore.IsZero()}
+	)
+
+	count, err := queries.CountMovies(ctx, db.CountMoviesParams{
+		Search:      q.Search,
+		Genre:       q.Genre,
+		Country:     q.Country,
+		AddedAfter:  addedAfter,
+		AddedBefore: addedBefore,
+		MinRating:   q.MinRating,
+		MaxRating:   q.MaxRating,
+	})
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	genres, err := queries.CountGenres(ctx, db.CountGenresParams{
+		Search:      q.Search,
+		Genre:       q.Genre,
+		Country:     q.Country,
+		AddedAfter:  addedAfter,
+		AddedBefore: addedBefore,
+		MinRating:   q.MinRating,
+		MaxRating:   q.MaxRating,
+	})
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	countries, err := queries.CountCountries(ctx, db.CountCountriesParams{
+		Search:      q.Search,
+		Genre:       q.Genre,
+		Country:     q.Country,
+		AddedAfter:  addedAfter,
+		AddedBefore: addedBefore,
+		MinRating:   q.MinRating,
+		MaxRating:   q.MaxRating,
+	})
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	facets := benchflix.Facets{
+		Count:     count,
+		Genres:    make([]benchflix.FacetCount, len(genres)),
+		Countries: make([]benchflix.FacetCount, len(countries)),
+	}
+
+	for i, row := range genres {
+		facets.Genres[i] = benchflix.FacetCount{Name: row.Name, Count: row.Count}
+	}
+
+	for i, row := range countries {
+		facets.Countries[i] = benchflix.FacetCount{Name: row.Name, Count: row.Count}
 	}
 
-	return strings.Split(s, ",")
+	return facets, nil
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"iter"
+	"slices"
 
-	_ "github.com/mattn/go-sqlite3"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 	"github.com/wroge/sqlt"
 )
 
@@ -30,6 +32,16 @@ type MovieGenres struct {
 	GenreIDs []int64
 }
 
+type Name struct {
+	ID   int64
+	Name string
+}
+
+type Link struct {
+	MovieID int64
+	ID      int64
+}
+
 var (
 	config = sqlt.Config{
 		Cache: &sqlt.Cache{},
@@ -140,6 +152,69 @@ var (
 		{{ end }}
 	`))
 
+	insertMovies = sqlt.Exec[[]benchflix.Movie](config, sqlt.Parse(`
+		INSERT INTO movies (id, title, added_at, rating) VALUES
+		{{ range $i, $m := . }}
+			{{ if $i }}, {{ end }}
+			({{ $m.ID }}, {{ $m.Title }}, {{ $m.AddedAt }}, {{ $m.Rating }})
+		{{ end }};
+	`))
+	upsertPeople = sqlt.All[[]string, Name](config, sqlt.Parse(`
+		INSERT INTO people (name) VALUES
+		{{ range $i, $p := . }}
+			{{ if $i }}, {{ end }}
+			({{ $p }})
+		{{ end }}
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, {{ Scan "ID" }} name {{ Scan "Name" }};
+	`))
+	upsertCountries = sqlt.All[[]string, Name](config, sqlt.Parse(`
+		INSERT INTO countries (name) VALUES
+		{{ range $i, $p := . }}
+			{{ if $i }}, {{ end }}
+			({{ $p }})
+		{{ end }}
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, {{ Scan "ID" }} name {{ Scan "Name" }};
+	`))
+	upsertGenres = sqlt.All[[]string, Name](config, sqlt.Parse(`
+		INSERT INTO genres (name) VALUES
+		{{ range $i, $p := . }}
+			{{ if $i }}, {{ end }}
+			({{ $p }})
+		{{ end }}
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, {{ Scan "ID" }} name {{ Scan "Name" }};
+	`))
+	insertDirectorLinks = sqlt.Exec[[]Link](config, sqlt.Parse(`
+		INSERT INTO movie_directors (movie_id, person_id) VALUES
+		{{ range $i, $l := . }}
+			{{ if $i }}, {{ end }}
+			({{ $l.MovieID }}, {{ $l.ID }})
+		{{ end }}
+	`))
+	insertActorLinks = sqlt.Exec[[]Link](config, sqlt.Parse(`
+		INSERT INTO movie_actors (movie_id, person_id) VALUES
+		{{ range $i, $l := . }}
+			{{ if $i }}, {{ end }}
+			({{ $l.MovieID }}, {{ $l.ID }})
+		{{ end }}
+	`))
+	insertCountryLinks = sqlt.Exec[[]Link](config, sqlt.Parse(`
+		INSERT INTO movie_countries (movie_id, country_id) VALUES
+		{{ range $i, $l := . }}
+			{{ if $i }}, {{ end }}
+			({{ $l.MovieID }}, {{ $l.ID }})
+		{{ end }}
+	`))
+	insertGenreLinks = sqlt.Exec[[]Link](config, sqlt.Parse(`
+		INSERT INTO movie_genres (movie_id, genre_id) VALUES
+		{{ range $i, $l := . }}
+			{{ if $i }}, {{ end }}
+			({{ $l.MovieID }}, {{ $l.ID }})
+		{{ end }}
+	`))
+
 	first = sqlt.First[int64, benchflix.Movie](config, sqlt.Parse(`
 		SELECT
 			movies.id,			{{ Scan "ID" }}
@@ -147,114 +222,232 @@ var (
 			movies.added_at,	{{ Scan "AddedAt" }}
 			movies.rating,		{{ Scan "Rating" }}
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_directors
 				JOIN people ON people.id = movie_directors.person_id
 				WHERE movie_directors.movie_id = movies.id
-			) AS directors,		{{ ScanStringSlice "Directors" "," }}
+			) AS directors,		{{ ScanJSON "Directors" }}
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_actors
 				JOIN people ON people.id = movie_actors.person_id
 				WHERE movie_actors.movie_id = movies.id
-			) AS actors,		{{ ScanStringSlice "Actors" "," }}
+			) AS actors,		{{ ScanJSON "Actors" }}
 			(
-				SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
+				SELECT json_group_array(countries.name ORDER BY countries.name)
 				FROM movie_countries
 				JOIN countries ON countries.id = movie_countries.country_id
 				WHERE movie_countries.movie_id = movies.id
-			) AS countries,		{{ ScanStringSlice "Countries" "," }}
+			) AS countries,		{{ ScanJSON "Countries" }}
 			(
-				SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
+				SELECT json_group_array(genres.name ORDER BY genres.name)
 				FROM movie_genres
 				JOIN genres ON genres.id = movie_genres.genre_id
 				WHERE movie_genres.movie_id = movies.id
-			) AS genres 		{{ ScanStringSlice "Genres" "," }}
+			) AS genres 		{{ ScanJSON "Genres" }}
 		FROM movies
 		WHERE movies.id = {{ . }}
 		ORDER BY movies.title ASC;
 	`))
 
-	all = sqlt.All[benchflix.Query, benchflix.Movie](config, sqlt.Parse(`
+	// filter is the WHERE clause shared by the statements that take a Query.
+	filter = sqlt.Parse(`
+		{{ define "filter" }}
+			{{ if .Search }}
+				AND (
+					EXISTS (
+						SELECT 1 FROM movie_directors
+						JOIN people ON people.id = movie_directors.person_id
+						WHERE movie_directors.movie_id = movies.id
+						AND INSTR(people.name, {{ .Search }}) > 0
+					)
+					OR EXISTS (
+						SELECT 1 FROM movie_actors
+						JOIN people ON people.id = movie_actors.person_id
+						WHERE movie_actors.movie_id = movies.id
+						AND INSTR(people.name, {{ .Search }}) > 0
+					)
+				)
+			{{ end }}
+			{{ if .Genre }}
+				AND EXISTS (
+					SELECT 1 FROM movie_genres
+					JOIN genres ON genres.id = movie_genres.genre_id
+					WHERE movie_genres.movie_id = movies.id
+					AND genres.name = {{ .Genre }}
+				)
+			{{ end }}
+			{{ if .Country }}
+				AND EXISTS (
+					SELECT 1 FROM movie_countries
+					JOIN countries ON countries.id = movie_countries.country_id
+					WHERE movie_countries.movie_id = movies.id
+					AND countries.name = {{ .Country }}
+				)
+			{{ end }}
+			{{ if not .AddedBefore.IsZero }}
+				AND added_at < {{ .AddedBefore }}
+			{{ end }}
+			{{ if not .AddedAfter.IsZero }}
+				AND added_at > {{ .AddedAfter }}
+			{{ end }}
+			{{ if gt .MinRating 0.0 }}
+				AND rating >= {{ .MinRating }}
+			{{ end }}
+			{{ if gt .MaxRating 0.0 }}
+				AND rating <= {{ .MaxRating }}
+			{{ end }}
+		{{ end }}
+	`)
+
+	selectMovies = sqlt.Parse(`
 		SELECT
 			movies.id,			{{ Scan "ID" }}
 			movies.title,		{{ Scan "Title" }}
 			movies.added_at,	{{ Scan "AddedAt" }}
 			movies.rating,		{{ Scan "Rating" }}
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_directors
 				JOIN people ON people.id = movie_directors.person_id
 				WHERE movie_directors.movie_id = movies.id
-			) AS directors,		{{ ScanStringSlice "Directors" "," }}
+			) AS directors,		{{ ScanJSON "Directors" }}
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_actors
 				JOIN people ON people.id = movie_actors.person_id
 				WHERE movie_actors.movie_id = movies.id
-			) AS actors,		{{ ScanStringSlice "Actors" "," }}
+			) AS actors,		{{ ScanJSON "Actors" }}
 			(
-				SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
+				SELECT json_group_array(countries.name ORDER BY countries.name)
 				FROM movie_countries
 				JOIN countries ON countries.id = movie_countries.country_id
 				WHERE movie_countries.movie_id = movies.id
-			) AS countries,		{{ ScanStringSlice "Countries" "," }}
+			) AS countries,		{{ ScanJSON "Countries" }}
 			(
-				SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
+				SELECT json_group_array(genres.name ORDER BY genres.name)
 				FROM movie_genres
 				JOIN genres ON genres.id = movie_genres.genre_id
 				WHERE movie_genres.movie_id = movies.id
-			) AS genres 		{{ ScanStringSlice "Genres" "," }}
+			) AS genres 		{{ ScanJSON "Genres" }}
 		FROM movies
 		WHERE 1=1
-		{{ if .Search }}
-			AND (
-				EXISTS (
-					SELECT 1 FROM movie_directors
-					JOIN people ON people.id = movie_directors.person_id
-					WHERE movie_directors.movie_id = movies.id
-					AND INSTR(people.name, {{ .Search }}) > 0
-				)
-				OR EXISTS (
-					SELECT 1 FROM movie_actors
-					JOIN people ON people.id = movie_actors.person_id
-					WHERE movie_actors.movie_id = movies.id
-					AND INSTR(people.name, {{ .Search }}) > 0
-				)
-			)
-		{{ end }}
-		{{ if .Genre }}
-			AND EXISTS (
-				SELECT 1 FROM movie_genres
-				JOIN genres ON genres.id = movie_genres.genre_id
-				WHERE movie_genres.movie_id = movies.id
-				AND genres.name = {{ .Genre }}
-			)
-		{{ end }}
-		{{ if .Country }}
-			AND EXISTS (
-				SELECT 1 FROM movie_countries
-				JOIN countries ON countries.id = movie_countries.country_id
-				WHERE movie_countries.movie_id = movies.id
-				AND countries.name = {{ .Country }}
-			)
-		{{ end }}
-		{{ if not .AddedBefore.IsZero }}
-			AND added_at < {{ .AddedBefore }}
-		{{ end }}
-		{{ if not .AddedAfter.IsZero }}
-			AND added_at > {{ .AddedAfter }}
-		{{ end }}
-		{{ if .MinRating }}
-			AND rating >= {{ .MinRating }}
+		{{ template "filter" . }}
+		{{ if .After }}
+			AND (movies.{{ Raw .Sort.Column }}, movies.id) {{ if .Sort.Descending }}<{{ else }}>{{ end }}
+			({{ .After.Value .Sort.Field }}, {{ .After.ID }})
 		{{ end }}
-		{{ if .MaxRating }}
-			AND rating <= {{ .MaxRating }}
-		{{ end }}
-		ORDER BY movies.title ASC
+		ORDER BY movies.{{ Raw .Sort.Column }} {{ Raw .Sort.Direction }}, movies.id {{ Raw .Sort.Direction }}
 		{{ if .Limit }}
 			LIMIT {{ .Limit }}
+		{{ else if .Offset }}
+			LIMIT -1
+		{{ end }}
+		{{ if .Offset }}
+			OFFSET {{ .Offset }}
 		{{ end }};
+	`)
+
+	all = sqlt.All[benchflix.Query, benchflix.Movie](config, filter, selectMovies)
+
+	stream = sqlt.Stmt[benchflix.Query](sqlt.QueryMode,
+		func(ctx context.Context, db sqlt.DB, expr sqlt.Expression[benchflix.Movie]) (iter.Seq2[benchflix.Movie, error], error) {
+			return func(yield func(benchflix.Movie, error) bool) {
+				rows, err := db.QueryContext(ctx, expr.SQL, expr.Args...)
+				if err != nil {
+					yield(benchflix.Movie{}, err)
+
+					return
+				}
+
+				defer rows.Close()
+
+				values, mapper, err := expr.DestMapper(rows)
+				if err != nil {
+					yield(benchflix.Movie{}, err)
+
+					return
+				}
+
+				for rows.Next() {
+					var movie benchflix.Movie
+
+					if err = rows.Scan(values...); err != nil {
+						yield(benchflix.Movie{}, err)
+
+						return
+					}
+
+					if err = mapper(&movie); err != nil {
+						yield(benchflix.Movie{}, err)
+
+						return
+					}
+
+					if !yield(movie, nil) {
+						return
+					}
+				}
+
+				if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+					yield(benchflix.Movie{}, err)
+				}
+			}, nil
+		},
+		config, filter, selectMovies,
+	)
+
+	countMovies = sqlt.First[benchflix.Query, int64](config, filter, sqlt.Parse(`
+		SELECT COUNT(*) {{ Scan "" }}
+		FROM movies
+		WHERE 1=1
+		{{ template "filter" . }};
+	`))
+
+	genreFacets = sqlt.All[benchflix.Query, benchflix.FacetCount](config, filter, sqlt.Parse(`
+		SELECT
+			genres.name,	{{ Scan "Name" }}
+			COUNT(*)		{{ Scan "Count" }}
+		FROM movie_genres
+		JOIN genres ON genres.id = movie_genres.genre_id
+		JOIN movies ON movies.id = movie_genres.movie_id
+		WHERE 1=1
+		{{ template "filter" . }}
+		GROUP BY genres.name
+		ORDER BY COUNT(*) DESC, genres.name ASC;
+	`))
+
+	countryFacets = sqlt.All[benchflix.Query, benchflix.FacetCount](config, filter, sqlt.Parse(`
+		SELECT
+			countries.name,	{{ Scan "Name" }}
+			COUNT(*)		{{ Scan "Count" }}
+		FROM movie_countries
+		JOIN countries ON countries.id = movie_countries.country_id
+		JOIN movies ON movies.id = movie_countries.movie_id
+		WHERE 1=1
+		{{ template "filter" . }}
+		GROUP BY countries.name
+		ORDER BY COUNT(*) DESC, countries.name ASC;
+	`))
+
+	updateMovie = sqlt.Exec[benchflix.Movie](config, sqlt.Parse(`
+		UPDATE movies SET
+			title = {{ .Title }},
+			added_at = {{ .AddedAt }},
+			rating = {{ .Rating }}
+		WHERE id = {{ .ID }};
+	`))
+	deleteMovieDirectors = sqlt.Exec[int64](config, sqlt.Parse(`
+		DELETE FROM movie_directors WHERE movie_id = {{ . }};
+	`))
+	deleteMovieActors = sqlt.Exec[int64](config, sqlt.Parse(`
+		DELETE FROM movie_actors WHERE movie_id = {{ . }};
+	`))
+	deleteMovieCountries = sqlt.Exec[int64](config, sqlt.Parse(`
+		DELETE FROM movie_countries WHERE movie_id = {{ . }};
+	`))
+	deleteMovieGenres = sqlt.Exec[int64](config, sqlt.Parse(`
+		DELETE FROM movie_genres WHERE movie_id = {{ . }};
 	`))
 
 	deleteMovie = sqlt.Exec[int64](config, sqlt.Parse(`
@@ -262,19 +455,23 @@ var (
 	`))
 )
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
+func init() {
+	drivers.Register("sqlt", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
 	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if _, err = schema.Exec(context.Background(), db, nil); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	return Repository{
 		DB: db,
-	}
+	}, nil
 }
 
 type Repository struct {
@@ -282,17 +479,24 @@ type Repository struct {
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	_, err := deleteMovie.Exec(ctx, r.DB, id)
+	result, err := deleteMovie.Exec(ctx, r.DB, id)
+	if err != nil {
+		return err
+	}
 
-	return err
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
-	var (
-		actorsLen    = len(movie.Actors)
-		directorsLen = len(movie.Directors)
-	)
-
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -307,10 +511,177 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 	}()
 
 	_, err = insertMovie.Exec(ctx, tx, movie)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := updateMovie.Exec(ctx, tx, movie)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, stmt := range []sqlt.Statement[int64, sql.Result]{
+		deleteMovieDirectors, deleteMovieActors, deleteMovieCountries, deleteMovieGenres,
+	} {
+		if _, err = stmt.Exec(ctx, tx, movie.ID); err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+const batchSize = 1000
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var people, countries, genres []string
+
+	for batch := range slices.Chunk(movies, batchSize) {
+		if _, err = insertMovies.Exec(ctx, tx, batch); err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+
+		for _, movie := range batch {
+			people = append(append(people, movie.Directors...), movie.Actors...)
+			countries = append(countries, movie.Countries...)
+			genres = append(genres, movie.Genres...)
+		}
+	}
+
+	personIDs, err := upsertNames(ctx, tx, upsertPeople, people)
+	if err != nil {
+		return err
+	}
+
+	countryIDs, err := upsertNames(ctx, tx, upsertCountries, countries)
+	if err != nil {
+		return err
+	}
+
+	genreIDs, err := upsertNames(ctx, tx, upsertGenres, genres)
+	if err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, tx, insertDirectorLinks, movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Directors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, tx, insertActorLinks, movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Actors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, tx, insertCountryLinks, movies, countryIDs,
+		func(movie benchflix.Movie) []string { return movie.Countries }); err != nil {
+		return err
+	}
+
+	return insertLinks(ctx, tx, insertGenreLinks, movies, genreIDs,
+		func(movie benchflix.Movie) []string { return movie.Genres })
+}
+
+func upsertNames(
+	ctx context.Context,
+	tx *sql.Tx,
+	stmt sqlt.Statement[[]string, []Name],
+	names []string,
+) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+
+	for batch := range slices.Chunk(benchflix.Unique(names), batchSize) {
+		result, err := stmt.Exec(ctx, tx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range result {
+			ids[name.Name] = name.ID
+		}
+	}
+
+	return ids, nil
+}
+
+func insertLinks(
+	ctx context.Context,
+	tx *sql.Tx,
+	stmt sqlt.Statement[[]Link, sql.Result],
+	movies []benchflix.Movie,
+	ids map[string]int64,
+	names func(benchflix.Movie) []string,
+) error {
+	var links []Link
+
+	for _, movie := range movies {
+		for _, name := range names(movie) {
+			links = append(links, Link{MovieID: movie.ID, ID: ids[name]})
+		}
+	}
+
+	for batch := range slices.Chunk(links, batchSize) {
+		if _, err := stmt.Exec(ctx, tx, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertRelations(ctx context.Context, tx *sql.Tx, movie benchflix.Movie) error {
+	var (
+		actorsLen    = len(movie.Actors)
+		directorsLen = len(movie.Directors)
+	)
+
 	if actorsLen+directorsLen > 0 {
 		people, err := insertPeople.Exec(ctx, tx, append(movie.Directors, movie.Actors...))
 		if err != nil {
@@ -375,6 +746,47 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 	return all.Exec(ctx, r.DB, query)
 }
 
+func (r Repository) Stream(ctx context.Context, query benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		movies, err := stream.Exec(ctx, r.DB, query)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		movies(yield)
+	}
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	count, err := countMovies.Exec(ctx, r.DB, query)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	genres, err := genreFacets.Exec(ctx, r.DB, query)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	countries, err := countryFacets.Exec(ctx, r.DB, query)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	return benchflix.Facets{
+		Count:     count,
+		Genres:    genres,
+		Countries: countries,
+	}, nil
+}
+
 func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
-	return first.Exec(ctx, r.DB, id)
+	movie, err := first.Exec(ctx, r.DB, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return benchflix.Movie{}, benchflix.ErrNotFound
+	}
+
+	return movie, err
 }
@@ -0,0 +1,189 @@
+package sqlxflix
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// db returns the sqlx.ExtContext of the repository outside of a transaction.
+func (r Repository) db() sqlx.ExtContext {
+	if r.Statements == nil {
+		return r.DB
+	}
+
+	return prepared{statements: r.Statements}
+}
+
+// tx returns the sqlx.ExtContext of the repository within tx.
+func (r Repository) tx(tx *sqlx.Tx) sqlx.ExtContext {
+	if r.Statements == nil {
+		return tx
+	}
+
+	return prepared{statements: r.Statements, tx: tx}
+}
+
+// Statements prepares every distinct query once and reuses it. Queries that
+// are built with a variable number of rows or conditions get a statement per
+// shape. A query that is first used in a transaction is prepared on the
+// connection of the transaction and, after the commit, on the pool, so that
+// later transactions bind the statement with tx.StmtxContext.
+type Statements struct {
+	db    *sqlx.DB
+	mu    sync.RWMutex
+	stmts map[string]*sqlx.Stmt
+	// missed are the queries that transactions prepared for themselves.
+	missed []string
+}
+
+// Prepare returns the prepared statement of query.
+func (s *Statements) Prepare(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	if stmt, ok := s.lookup(query); ok {
+		return stmt, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stmt, ok := s.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := s.db.PreparexContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+func (s *Statements) lookup(query string) (*sqlx.Stmt, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stmt, ok := s.stmts[query]
+
+	return stmt, ok
+}
+
+// miss remembers a query that a transaction prepared for itself.
+func (s *Statements) miss(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.missed = append(s.missed, query)
+}
+
+// prepareMissed prepares the missed queries on the pool.
+func (s *Statements) prepareMissed(ctx context.Context) error {
+	s.mu.Lock()
+	missed := s.missed
+	s.missed = nil
+	s.mu.Unlock()
+
+	for _, query := range missed {
+		if _, err := s.Prepare(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit commits tx and, in prepared mode, prepares the queries that tx
+// prepared for itself, now that the pool has a free connection again.
+func (r Repository) commit(ctx context.Context, tx *sqlx.Tx) error {
+	if err := tx.Commit(); err != nil || r.Statements == nil {
+		return err
+	}
+
+	return r.Statements.prepareMissed(ctx)
+}
+
+// prepared runs queries as prepared statements, bound to tx if it is not nil.
+// Named queries are bound by the binder of the database.
+type prepared struct {
+	statements *Statements
+	tx         *sqlx.Tx
+}
+
+func (p prepared) ext() sqlx.ExtContext {
+	if p.tx != nil {
+		return p.tx
+	}
+
+	return p.statements.db
+}
+
+func (p prepared) stmt(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	if p.tx == nil {
+		return p.statements.Prepare(ctx, query)
+	}
+
+	if stmt, ok := p.statements.lookup(query); ok {
+		return p.tx.StmtxContext(ctx, stmt), nil
+	}
+
+	// Preparing on the pool would take a second connection while tx holds
+	// one, which is another, empty database in memory. So the query is
+	// prepared on the connection of the transaction, closed with it, and
+	// prepared on the pool after the commit.
+	p.statements.miss(query)
+
+	return p.tx.PreparexContext(ctx, query)
+}
+
+func (p prepared) DriverName() string {
+	return p.statements.db.DriverName()
+}
+
+func (p prepared) Rebind(query string) string {
+	return p.statements.db.Rebind(query)
+}
+
+func (p prepared) BindNamed(query string, arg any) (string, []any, error) {
+	return p.statements.db.BindNamed(query, arg)
+}
+
+func (p prepared) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (p prepared) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (p prepared) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryxContext(ctx, args...)
+}
+
+func (p prepared) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	stmt, err := p.stmt(ctx, query)
+	if err != nil {
+		// A *sqlx.Row cannot be created with an error, so the unprepared query
+		// reports it instead.
+		return p.ext().QueryRowxContext(ctx, query, args...)
+	}
+
+	return stmt.QueryRowxContext(ctx, args...)
+}
@@ -3,19 +3,39 @@ package sqlxflix
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/mattn/go-sqlite3"
 	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
 )
 
-func NewRepository(driverName, dataSourceName string) benchflix.Repository {
+func init() {
+	drivers.Register("sqlx", NewRepository)
+	drivers.Register("sqlx-prepared", NewPreparedRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	return New(driverName, dataSourceName, false)
+}
+
+// NewPreparedRepository returns a Repository in prepared mode.
+func NewPreparedRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	return New(driverName, dataSourceName, true)
+}
+
+// New opens the database and creates the schema. In prepared mode, the
+// statements of Read, Create, Update and Delete are prepared right away and all
+// others the first time they are used.
+func New(driverName, dataSourceName string, prepared bool) (Repository, error) {
 	sqldb, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
-		panic(err)
+		return Repository{}, err
 	}
 
 	db := sqlx.NewDb(sqldb, driverName)
@@ -67,22 +87,74 @@ func NewRepository(driverName, dataSourceName string) benchflix.Repository {
 			PRIMARY KEY (movie_id, genre_id)
 		);`)
 	if err != nil {
-		panic(err)
+		return Repository{}, err
 	}
 
-	return Repository{
-		DB: db,
+	if !prepared {
+		return Repository{
+			DB: db,
+		}, nil
+	}
+
+	statements := &Statements{
+		db:    db,
+		stmts: map[string]*sqlx.Stmt{},
 	}
+
+	for _, query := range []string{readMovie, insertMovie, updateMovie, deleteMovie} {
+		if _, err = statements.Prepare(context.Background(), query); err != nil {
+			return Repository{}, err
+		}
+	}
+
+	for _, table := range links {
+		if _, err = statements.Prepare(context.Background(), deleteLinks(table)); err != nil {
+			return Repository{}, err
+		}
+	}
+
+	return Repository{
+		DB:         db,
+		Statements: statements,
+	}, nil
 }
 
 type Repository struct {
 	DB *sqlx.DB
+	// Statements holds the prepared statements of DB. It is nil, unless the
+	// repository is in prepared mode.
+	Statements *Statements
+}
+
+const (
+	insertMovie = `INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?);`
+	updateMovie = `UPDATE movies SET title = ?, added_at = ?, rating = ? WHERE id = ?;`
+	deleteMovie = `DELETE FROM movies WHERE id = ?;`
+)
+
+// links are the tables that link movies to people, countries and genres.
+var links = []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"}
+
+func deleteLinks(table string) string {
+	return "DELETE FROM " + table + " WHERE movie_id = ?;"
 }
 
 func (r Repository) Delete(ctx context.Context, id int64) error {
-	_, err := r.DB.ExecContext(ctx, "DELETE FROM movies WHERE id = ?", id)
+	result, err := r.db().ExecContext(ctx, deleteMovie, id)
+	if err != nil {
+		return err
+	}
 
-	return err
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
 }
 
 func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
@@ -95,18 +167,214 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 		if err != nil {
 			err = errors.Join(err, tx.Rollback())
 		} else {
-			err = tx.Commit()
+			err = r.commit(ctx, tx)
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx,
-		`INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?);`,
+	_, err = r.tx(tx).ExecContext(ctx, insertMovie,
 		movie.ID, movie.Title, movie.AddedAt, movie.Rating,
 	)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, r.tx(tx), movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = r.commit(ctx, tx)
+		}
+	}()
+
+	result, err := r.tx(tx).ExecContext(ctx, updateMovie,
+		movie.Title, movie.AddedAt, movie.Rating, movie.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range links {
+		if _, err = r.tx(tx).ExecContext(ctx, deleteLinks(table), movie.ID); err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, r.tx(tx), movie)
+}
+
+const batchSize = 1000
+
+type Name struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type Link struct {
+	MovieID int64 `db:"movie_id"`
+	ID      int64 `db:"id"`
+}
+
+func (r Repository) CreateMany(ctx context.Context, movies []benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = r.commit(ctx, tx)
+		}
+	}()
+
+	var people, countries, genres []string
+
+	for batch := range slices.Chunk(movies, batchSize) {
+		_, err = sqlx.NamedExecContext(ctx, r.tx(tx),
+			`INSERT INTO movies (id, title, added_at, rating) VALUES (:id, :title, :added_at, :rating)`,
+			batch,
+		)
+		if err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+
+		for _, movie := range batch {
+			people = append(append(people, movie.Directors...), movie.Actors...)
+			countries = append(countries, movie.Countries...)
+			genres = append(genres, movie.Genres...)
+		}
+	}
+
+	personIDs, err := insertNames(ctx, r.tx(tx), "people", people)
+	if err != nil {
+		return err
+	}
+
+	countryIDs, err := insertNames(ctx, r.tx(tx), "countries", countries)
+	if err != nil {
+		return err
+	}
+
+	genreIDs, err := insertNames(ctx, r.tx(tx), "genres", genres)
+	if err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_directors (movie_id, person_id)", movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Directors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_actors (movie_id, person_id)", movies, personIDs,
+		func(movie benchflix.Movie) []string { return movie.Actors }); err != nil {
+		return err
+	}
+
+	if err = insertLinks(ctx, r.tx(tx), "movie_countries (movie_id, country_id)", movies, countryIDs,
+		func(movie benchflix.Movie) []string { return movie.Countries }); err != nil {
+		return err
+	}
+
+	return insertLinks(ctx, r.tx(tx), "movie_genres (movie_id, genre_id)", movies, genreIDs,
+		func(movie benchflix.Movie) []string { return movie.Genres })
+}
+
+func insertNames(ctx context.Context, tx sqlx.ExtContext, table string, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(names))
+
+	for batch := range slices.Chunk(benchflix.Unique(names), batchSize) {
+		args := make([]Name, len(batch))
+
+		for i, name := range batch {
+			args[i] = Name{Name: name}
+		}
+
+		rows, err := sqlx.NamedQueryContext(ctx, tx,
+			fmt.Sprintf(
+				`INSERT INTO %s (name) VALUES (:name) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name`,
+				table,
+			),
+			args,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var name Name
+
+			if err = rows.StructScan(&name); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+
+			ids[name.Name] = name.ID
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+func insertLinks(
+	ctx context.Context,
+	tx sqlx.ExtContext,
+	table string,
+	movies []benchflix.Movie,
+	ids map[string]int64,
+	names func(benchflix.Movie) []string,
+) error {
+	var links []Link
+
+	for _, movie := range movies {
+		for _, name := range names(movie) {
+			links = append(links, Link{MovieID: movie.ID, ID: ids[name]})
+		}
+	}
+
+	for batch := range slices.Chunk(links, batchSize) {
+		_, err := sqlx.NamedExecContext(ctx, tx,
+			fmt.Sprintf(`INSERT INTO %s VALUES (:movie_id, :id)`, table),
+			batch,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertRelations(ctx context.Context, tx sqlx.ExtContext, movie benchflix.Movie) (err error) {
 	if len(movie.Directors) > 0 {
 		directorNames := make([]any, len(movie.Directors))
 
@@ -116,7 +384,7 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 
 		directorIDs := make([]int64, len(directorNames))
 
-		err = tx.SelectContext(ctx,
+		err = sqlx.SelectContext(ctx, tx,
 			&directorIDs,
 			fmt.Sprintf(
 				`INSERT INTO people (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id`,
@@ -155,7 +423,7 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 
 		actorIDs := make([]int64, len(actorNames))
 
-		err = tx.SelectContext(ctx,
+		err = sqlx.SelectContext(ctx, tx,
 			&actorIDs,
 			fmt.Sprintf(
 				`INSERT INTO people (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id`,
@@ -194,7 +462,7 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 
 		countryIDs := make([]int64, len(countryArgs))
 
-		err = tx.SelectContext(ctx,
+		err = sqlx.SelectContext(ctx, tx,
 			&countryIDs,
 			fmt.Sprintf(
 				`INSERT INTO countries (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id;`,
@@ -234,7 +502,7 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 
 		genreIDs := make([]int64, len(genreArgs))
 
-		err = tx.SelectContext(ctx,
+		err = sqlx.SelectContext(ctx, tx,
 			&genreIDs,
 			fmt.Sprintf(
 				`INSERT INTO genres (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id;`,
@@ -268,7 +536,8 @@ func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err erro
 	return nil
 }
 
-func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+// filter writes the conditions of query to a WHERE clause of the movies table.
+func filter(query benchflix.Query) (*strings.Builder, []any) {
 	builder := &strings.Builder{}
 	args := []any{}
 
@@ -333,134 +602,270 @@ func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchfl
 		args = append(args, query.MaxRating)
 	}
 
-	builder.WriteString(" ORDER BY movies.title ASC")
+	return builder, args
+}
 
-	if query.Limit > 0 {
-		builder.WriteString(" LIMIT ?")
+// selectMovies returns the statement and arguments of query.
+func selectMovies(query benchflix.Query) (string, []any, error) {
+	builder, args := filter(query)
 
-		args = append(args, query.Limit)
+	column, err := query.Sort.Column()
+	if err != nil {
+		return "", nil, err
 	}
 
-	var movies []Movie
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
 
-	err := r.DB.SelectContext(ctx,
-		&movies,
-		fmt.Sprintf(
-			`SELECT
-				movies.id,
-				movies.title,
-				movies.added_at,
-				movies.rating,
-				(
-					SELECT GROUP_CONCAT(people.name ORDER BY people.name)
-					FROM movie_directors
-					JOIN people ON people.id = movie_directors.person_id
-					WHERE movie_directors.movie_id = movies.id
-				) AS directors,
-				(
-					SELECT GROUP_CONCAT(people.name ORDER BY people.name)
-					FROM movie_actors
-					JOIN people ON people.id = movie_actors.person_id
-					WHERE movie_actors.movie_id = movies.id
-				) AS actors,
-				(
-					SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
-					FROM movie_countries
-					JOIN countries ON countries.id = movie_countries.country_id
-					WHERE movie_countries.movie_id = movies.id
-				) AS countries,
-				(
-					SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
-					FROM movie_genres
-					JOIN genres ON genres.id = movie_genres.genre_id
-					WHERE movie_genres.movie_id = movies.id
-				) AS genres
-			FROM movies
-			WHERE 1=1 %s;`,
-			builder,
-		),
-		args...,
-	)
-	if err != nil {
-		return nil, err
+		fmt.Fprintf(builder, ` AND (movies.%s, movies.id) %s (?, ?)`, column, operator)
+
+		args = append(args, query.After.Value(query.Sort.Field), query.After.ID)
 	}
 
-	result := make([]benchflix.Movie, len(movies))
+	fmt.Fprintf(builder, " ORDER BY movies.%s %s, movies.id %s", column, query.Sort.Direction(), query.Sort.Direction())
 
-	for i, m := range movies {
-		result[i] = ConvertMovie(m)
+	if query.Limit > 0 {
+		builder.WriteString(" LIMIT ?")
+
+		args = append(args, query.Limit)
 	}
 
-	return result, nil
-}
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			builder.WriteString(" LIMIT -1")
+		}
 
-type Movie struct {
-	benchflix.Movie
-	Directors, Actors, Countries, Genres sql.NullString
-}
+		builder.WriteString(" OFFSET ?")
 
-func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
-	var movie Movie
+		args = append(args, query.Offset)
+	}
 
-	err := r.DB.GetContext(ctx,
-		&movie,
+	return fmt.Sprintf(
 		`SELECT
 			movies.id,
 			movies.title,
 			movies.added_at,
 			movies.rating,
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_directors
 				JOIN people ON people.id = movie_directors.person_id
 				WHERE movie_directors.movie_id = movies.id
 			) AS directors,
 			(
-				SELECT GROUP_CONCAT(people.name ORDER BY people.name)
+				SELECT json_group_array(people.name ORDER BY people.name)
 				FROM movie_actors
 				JOIN people ON people.id = movie_actors.person_id
 				WHERE movie_actors.movie_id = movies.id
 			) AS actors,
 			(
-				SELECT GROUP_CONCAT(countries.name ORDER BY countries.name)
+				SELECT json_group_array(countries.name ORDER BY countries.name)
 				FROM movie_countries
 				JOIN countries ON countries.id = movie_countries.country_id
 				WHERE movie_countries.movie_id = movies.id
 			) AS countries,
 			(
-				SELECT GROUP_CONCAT(genres.name ORDER BY genres.name)
+				SELECT json_group_array(genres.name ORDER BY genres.name)
 				FROM movie_genres
 				JOIN genres ON genres.id = movie_genres.genre_id
 				WHERE movie_genres.movie_id = movies.id
 			) AS genres
 		FROM movies
-		WHERE id = ?
-		ORDER BY movies.title ASC;`,
-		id,
+		WHERE 1=1 %s;`,
+		builder,
+	), args, nil
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	text, args, err := selectMovies(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var movies []Movie
+
+	err = sqlx.SelectContext(ctx, r.db(), &movies, text, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, m := range movies {
+		result[i], err = ConvertMovie(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r Repository) Stream(ctx context.Context, query benchflix.Query) iter.Seq2[benchflix.Movie, error] {
+	return func(yield func(benchflix.Movie, error) bool) {
+		text, args, err := selectMovies(query)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		rows, err := r.db().QueryxContext(ctx, text, args...)
+		if err != nil {
+			yield(benchflix.Movie{}, err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var movie Movie
+
+			if err = rows.StructScan(&movie); err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			result, err := ConvertMovie(movie)
+			if err != nil {
+				yield(benchflix.Movie{}, err)
+
+				return
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+		}
+
+		if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+			yield(benchflix.Movie{}, err)
+		}
+	}
+}
+
+type Movie struct {
+	benchflix.Movie
+	Directors, Actors, Countries, Genres []byte
+}
+
+func (r Repository) Facets(ctx context.Context, query benchflix.Query) (benchflix.Facets, error) {
+	builder, args := filter(query)
+
+	var facets benchflix.Facets
+
+	err := sqlx.GetContext(ctx, r.db(), &facets.Count, fmt.Sprintf(`SELECT COUNT(*) FROM movies WHERE 1=1 %s;`, builder), args...)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = sqlx.SelectContext(ctx, r.db(), &facets.Genres,
+		fmt.Sprintf(
+			`SELECT genres.name AS name, COUNT(*) AS count
+			FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			JOIN movies ON movies.id = movie_genres.movie_id
+			WHERE 1=1 %s
+			GROUP BY genres.name
+			ORDER BY COUNT(*) DESC, genres.name ASC;`,
+			builder,
+		),
+		args...,
+	)
+	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	err = sqlx.SelectContext(ctx, r.db(), &facets.Countries,
+		fmt.Sprintf(
+			`SELECT countries.name AS name, COUNT(*) AS count
+			FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			JOIN movies ON movies.id = movie_countries.movie_id
+			WHERE 1=1 %s
+			GROUP BY countries.name
+			ORDER BY COUNT(*) DESC, countries.name ASC;`,
+			builder,
+		),
+		args...,
 	)
 	if err != nil {
+		return benchflix.Facets{}, err
+	}
+
+	return facets, nil
+}
+
+const readMovie = `SELECT
+		movies.id,
+		movies.title,
+		movies.added_at,
+		movies.rating,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_directors
+			JOIN people ON people.id = movie_directors.person_id
+			WHERE movie_directors.movie_id = movies.id
+		) AS directors,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_actors
+			JOIN people ON people.id = movie_actors.person_id
+			WHERE movie_actors.movie_id = movies.id
+		) AS actors,
+		(
+			SELECT json_group_array(countries.name ORDER BY countries.name)
+			FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id
+		) AS countries,
+		(
+			SELECT json_group_array(genres.name ORDER BY genres.name)
+			FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id
+		) AS genres
+	FROM movies
+	WHERE id = ?
+	ORDER BY movies.title ASC;`
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	err := sqlx.GetContext(ctx, r.db(), &movie, readMovie, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
 		return benchflix.Movie{}, err
 	}
 
-	return ConvertMovie(movie), nil
+	return ConvertMovie(movie)
 }
 
-func ConvertMovie(movie Movie) benchflix.Movie {
-	if movie.Directors.Valid {
-		movie.Movie.Directors = strings.Split(movie.Directors.String, ",")
+// ConvertMovie decodes the names aggregated with json_group_array.
+func ConvertMovie(movie Movie) (benchflix.Movie, error) {
+	if err := json.Unmarshal(movie.Directors, &movie.Movie.Directors); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if movie.Actors.Valid {
-		movie.Movie.Actors = strings.Split(movie.Actors.String, ",")
+	if err := json.Unmarshal(movie.Actors, &movie.Movie.Actors); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if movie.Countries.Valid {
-		movie.Movie.Countries = strings.Split(movie.Countries.String, ",")
+	if err := json.Unmarshal(movie.Countries, &movie.Movie.Countries); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	if movie.Genres.Valid {
-		movie.Movie.Genres = strings.Split(movie.Genres.String, ",")
+	if err := json.Unmarshal(movie.Genres, &movie.Movie.Genres); err != nil {
+		return benchflix.Movie{}, err
 	}
 
-	return movie.Movie
+	return movie.Movie, nil
 }
@@ -0,0 +1,412 @@
+package squirrelflix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+)
+
+func init() {
+	drivers.Register("squirrel", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: db,
+	}, nil
+}
+
+type Repository struct {
+	DB *sql.DB
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := sq.Delete("movies").
+		Where(sq.Eq{"id": id}).
+		RunWith(r.DB).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	_, err = sq.Insert("movies").
+		Columns("id", "title", "added_at", "rating").
+		Values(movie.ID, movie.Title, movie.AddedAt, movie.Rating).
+		RunWith(tx).
+		ExecContext(ctx)
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	result, err := sq.Update("movies").
+		Set("title", movie.Title).
+		Set("added_at", movie.AddedAt).
+		Set("rating", movie.Rating).
+		Where(sq.Eq{"id": movie.ID}).
+		RunWith(tx).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+		_, err = sq.Delete(table).
+			Where(sq.Eq{"movie_id": movie.ID}).
+			RunWith(tx).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(ctx, tx, movie)
+}
+
+func insertRelations(ctx context.Context, tx *sql.Tx, movie benchflix.Movie) error {
+	if err := insertNames(ctx, tx, "people", "movie_directors", "person_id", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "people", "movie_actors", "person_id", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "countries", "movie_countries", "country_id", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(ctx, tx, "genres", "movie_genres", "genre_id", movie.ID, movie.Genres)
+}
+
+// insertNames upserts names into table and links their IDs to the movie.
+func insertNames(ctx context.Context, tx *sql.Tx, table, links, column string, movieID int64, names []string) (err error) {
+	if len(names) == 0 {
+		return nil
+	}
+
+	insert := sq.Insert(table).
+		Columns("name").
+		Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id")
+
+	for _, name := range names {
+		insert = insert.Values(name)
+	}
+
+	rows, err := insert.RunWith(tx).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	link := sq.Insert(links).Columns("movie_id", column)
+
+	for rows.Next() {
+		var id int64
+
+		if err = rows.Scan(&id); err != nil {
+			return errors.Join(err, rows.Close())
+		}
+
+		link = link.Values(movieID, id)
+	}
+
+	if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+
+	_, err = link.RunWith(tx).ExecContext(ctx)
+
+	return err
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) sq.And {
+	conditions := sq.And{}
+
+	if query.Search != "" {
+		conditions = append(conditions, sq.Or{
+			exists("movie_directors", "people", "person_id", sq.Expr("INSTR(people.name, ?) > 0", query.Search)),
+			exists("movie_actors", "people", "person_id", sq.Expr("INSTR(people.name, ?) > 0", query.Search)),
+		})
+	}
+
+	if query.Genre != "" {
+		conditions = append(conditions, exists("movie_genres", "genres", "genre_id", sq.Eq{"genres.name": query.Genre}))
+	}
+
+	if query.Country != "" {
+		conditions = append(conditions, exists("movie_countries", "countries", "country_id", sq.Eq{"countries.name": query.Country}))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		conditions = append(conditions, sq.Lt{"movies.added_at": query.AddedBefore})
+	}
+
+	if !query.AddedAfter.IsZero() {
+		conditions = append(conditions, sq.Gt{"movies.added_at": query.AddedAfter})
+	}
+
+	if query.MinRating > 0 {
+		conditions = append(conditions, sq.GtOrEq{"movies.rating": query.MinRating})
+	}
+
+	if query.MaxRating > 0 {
+		conditions = append(conditions, sq.LtOrEq{"movies.rating": query.MaxRating})
+	}
+
+	return conditions
+}
+
+// exists matches movies linked to a row of table that satisfies condition.
+func exists(links, table, column string, condition sq.Sqlizer) sq.Sqlizer {
+	return sq.Expr("EXISTS (?)", sq.Select("1").
+		From(links).
+		Join(fmt.Sprintf("%[1]s ON %[1]s.id = %[2]s.%[3]s", table, links, column)).
+		Where(links+".movie_id = movies.id").
+		Where(condition))
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, table, column, alias string) sq.Sqlizer {
+	return sq.Alias(sq.Select(fmt.Sprintf("json_group_array(%[1]s.name ORDER BY %[1]s.name)", table)).
+		From(links).
+		Join(fmt.Sprintf("%[1]s ON %[1]s.id = %[2]s.%[3]s", table, links, column)).
+		Where(links+".movie_id = movies.id"), alias)
+}
+
+var selectMovies = sq.Select("movies.id", "movies.title", "movies.added_at", "movies.rating").
+	Column(aggregate("movie_directors", "people", "person_id", "directors")).
+	Column(aggregate("movie_actors", "people", "person_id", "actors")).
+	Column(aggregate("movie_countries", "countries", "country_id", "countries")).
+	Column(aggregate("movie_genres", "genres", "genre_id", "genres")).
+	From("movies")
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) (movies []benchflix.Movie, err error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := filter(query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		conditions = append(conditions, sq.Expr(
+			fmt.Sprintf("(movies.%s, movies.id) %s (?, ?)", column, operator),
+			query.After.Value(query.Sort.Field), query.After.ID,
+		))
+	}
+
+	stmt := selectMovies.
+		Where(conditions).
+		OrderBy(
+			fmt.Sprintf("movies.%s %s", column, query.Sort.Direction()),
+			"movies.id "+query.Sort.Direction(),
+		)
+
+	if query.Limit > 0 {
+		stmt = stmt.Limit(uint64(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			// SQLite does not accept OFFSET without LIMIT.
+			stmt = stmt.Limit(math.MaxInt64)
+		}
+
+		stmt = stmt.Offset(uint64(query.Offset))
+	}
+
+	rows, err := stmt.RunWith(r.DB).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = errors.Join(err, rows.Err(), rows.Close())
+	}()
+
+	for rows.Next() {
+		var (
+			movie                                benchflix.Movie
+			directors, actors, countries, genres []byte
+		)
+
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres); err != nil {
+			return nil, err
+		}
+
+		movie, err = ConvertMovie(movie, directors, actors, countries, genres)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var (
+		movie                                benchflix.Movie
+		directors, actors, countries, genres []byte
+	)
+
+	err := selectMovies.
+		Where(sq.Eq{"movies.id": id}).
+		RunWith(r.DB).
+		QueryRowContext(ctx).
+		Scan(&movie.ID, &movie.Title, &movie.AddedAt, &movie.Rating, &directors, &actors, &countries, &genres)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	return ConvertMovie(movie, directors, actors, countries, genres)
+}
+
+// ConvertMovie decodes the names aggregated with json_group_array.
+func ConvertMovie(movie benchflix.Movie, directors, actors, countries, genres []byte) (benchflix.Movie, error) {
+	if err := json.Unmarshal(directors, &movie.Directors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(actors, &movie.Actors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(countries, &movie.Countries); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(genres, &movie.Genres); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return movie, nil
+}
@@ -0,0 +1,28 @@
+SELECT 
+"movies"."id", "movies"."title", "movies"."added_at", "movies"."rating", (SELECT json_group_array(people.name ORDER BY people.name) FROM movie_directors JOIN people ON people.id = movie_directors.person_id WHERE movie_directors.movie_id = movies.id) AS directors, (SELECT json_group_array(people.name ORDER BY people.name) FROM movie_actors JOIN people ON people.id = movie_actors.person_id WHERE movie_actors.movie_id = movies.id) AS actors, (SELECT json_group_array(countries.name ORDER BY countries.name) FROM movie_countries JOIN countries ON countries.id = movie_countries.country_id WHERE movie_countries.movie_id = movies.id) AS countries, (SELECT json_group_array(genres.name ORDER BY genres.name) FROM movie_genres JOIN genres ON genres.id = movie_genres.genre_id WHERE movie_genres.movie_id = movies.id) AS genres
+FROM movies
+WHERE (EXISTS (SELECT 
+1
+FROM movie_directors
+INNER JOIN people ON ("people"."id" = "movie_directors"."person_id")
+WHERE ("movie_directors"."movie_id" = "movies"."id") AND INSTR(people.name, ?1) > 0
+) OR EXISTS (SELECT 
+1
+FROM movie_actors
+INNER JOIN people ON ("people"."id" = "movie_actors"."person_id")
+WHERE ("movie_actors"."movie_id" = "movies"."id") AND INSTR(people.name, ?2) > 0
+)) AND EXISTS (SELECT 
+1
+FROM movie_genres
+INNER JOIN genres ON ("genres"."id" = "movie_genres"."genre_id")
+WHERE ("movie_genres"."movie_id" = "movies"."id") AND ("genres"."name" = ?3)
+) AND EXISTS (SELECT 
+1
+FROM movie_countries
+INNER JOIN countries ON ("countries"."id" = "movie_countries"."country_id")
+WHERE ("movie_countries"."movie_id" = "movies"."id") AND ("countries"."name" = ?4)
+) AND ("movies"."added_at" < ?5) AND ("movies"."added_at" > ?6) AND ("movies"."rating" >= ?7) AND ("movies"."rating" <= ?8)
+ORDER BY "movies"."title" ASC, "movies"."id" ASC
+LIMIT 1
+
+
@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE (rating >= 5) ORDER BY movie."title" ASC, movie.id ASC LIMIT 10
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
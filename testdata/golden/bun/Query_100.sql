@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE (rating >= 5) ORDER BY movie."title" ASC, movie.id ASC LIMIT 100
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
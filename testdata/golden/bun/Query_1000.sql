@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE (rating >= 5) ORDER BY movie."title" ASC, movie.id ASC LIMIT 1000
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177, 2000179, 2000181, 2000183, 2000184, 2000186, 2000187, 2000188, 2000190, 2000192, 2000194, 2000196, 2000197, 2000199, 2000200, 2000202, 2000203, 2000204, 2000205, 2000207, 2000208, 2000209, 2000211, 2000214, 2000216, 2000218, 2000220, 2000221, 2000222, 2000223, 2000225, 2000227, 2000228, 2000230, 2000233, 2000236, 2000238, 2000244, 2000247, 2000248, 2000251, 2000253, 2000254, 2000255, 2000256, 2000259, 2000260, 2000261, 2000263, 2000264, 2000267, 2000268, 2000269, 2000271, 2000274, 2000276, 2000279, 2000284, 2000287, 2000288, 2000289, 2000290, 2000291, 2000292, 2000297, 2000298, 2000299, 2000300, 2000301, 2000305, 2000306, 2000307, 2000308, 2000310, 2000311, 2000312, 2000313, 2000315, 2000316, 2000317, 2000318, 2000319, 2000324, 2000327, 2000329, 2000330, 2000331, 2000332, 2000333, 2000334, 2000335, 2000336, 2000338, 2000339, 2000340, 2000342, 2000344, 2000345, 2000346, 2000347, 2000349, 2000350, 2000353, 2000355, 2000356, 2000357, 2000359, 2000362, 2000363, 2000366, 2000368, 2000374, 2000377, 2000379, 2000382, 2000383, 2000386, 2000391, 2000395, 2000398, 2000399, 2000402, 2000403, 2000404, 2000406, 2000407, 2000408, 2000411, 2000413, 2000415, 2000417, 2000420, 2000421, 2000422, 2000423, 2000425, 2000426, 2000427, 2000428, 2000429, 2000430, 2000433, 2000434, 2000435, 2000436, 2000437, 2000438, 2000440, 2000441, 2000445, 2000446, 2000447, 2000449, 2000450, 2000453, 2000454, 2000455, 2000456, 2000457, 2000458, 2000459, 2000460, 2000461, 2000464, 2000465, 2000466, 2000467, 2000468, 2000470, 2000471, 2000472, 2000473, 2000474, 2000475, 2000476, 2000477, 2000478, 2000480, 2000482, 2000484, 2000487, 2000488, 2000489, 2000492, 2000493, 2000495, 2000496, 2000498, 2000500, 2000501, 2000504, 2000505, 2000506, 2000508, 2000510, 2000511, 2000513, 2000515, 2000516, 2000517, 2000520, 2000522, 2000524, 2000528, 2000530, 2000531, 2000532, 2000533, 2000535, 2000536, 2000537, 2000540, 2000542, 2000543, 2000546, 2000547, 2000549, 2000550, 2000552, 2000553, 2000554, 2000556, 2000557, 2000559, 2000560, 2000561, 2000564, 2000566, 2000567, 2000568, 2000570, 2000571, 2000574, 2000578, 2000581, 2000582, 2000583, 2000584, 2000588, 2000589, 2000590, 2000592, 2000594, 2000595, 2000598, 2000601, 2000602, 2000604, 2000609, 2000611, 2000615, 2000616, 2000621, 2000623, 2000626, 2000632, 2000633, 2000637, 2000638, 2000640, 2000641, 2000642, 2000643, 2000650, 2000651, 2000652, 2000653, 2000656, 2000660, 2000661, 2000663, 2000664, 2000668, 2000669, 2000671, 2000673, 2000674, 2000675, 2000676, 2000677, 2000678, 2000679, 2000681, 2000683, 2000687, 2000689, 2000690, 2000691, 2000692, 2000693, 2000695, 2000696, 2000698, 2000700, 2000701, 2000702, 2000704, 2000706, 2000707, 2000709, 2000712, 2000713, 2000714, 2000715, 2000718, 2000719, 2000720, 2000721, 2000724, 2000725, 2000728, 2000730, 2000732, 2000734, 2000739, 2000744, 2000750, 2000754, 2000755, 2000757, 2000758, 2000762, 2000764, 2000766, 2000767, 2000768, 2000769, 2000775, 2000776, 2000777, 2000778, 2000779, 2000781, 2000782, 2000786, 2000787, 2000788, 2000789, 2000792, 2000794, 2000795, 2000797, 2000798, 2000802, 2000804, 2000805, 2000806, 2000807, 2000808, 2000810, 2000812, 2000814, 2000816, 2000819, 2000820, 2000821, 2000822, 2000825, 2000826, 2000830, 2000831, 2000833, 2000835, 2000836, 2000837, 2000839, 2000841, 2000842, 2000843, 2000844, 2000847, 2000848, 2000849, 2000850, 2000852, 2000853, 2000854, 2000856, 2000858, 2000859, 2000862, 2000863, 2000865, 2000869, 2000870, 2000874, 2000875, 2000876, 2000877, 2000878, 2000879, 2000880, 2000881, 2000882, 2000884, 2000885, 2000887, 2000889, 2000890, 2000892, 2000894, 2000895, 2000897, 2000900, 2000902, 2000903, 2000906, 2000907, 2000912, 2000914, 2000917, 2000922, 2000924, 2000926, 2000927, 2000929, 2000930, 2000931, 2000934, 2000937, 2000938, 2000943, 2000944, 2000945, 2000946, 2000950, 2000952, 2000953, 2000955, 2000957, 2000958, 2000959, 2000960, 2000961, 2000962, 2000964, 2000967, 2000970, 2000971, 2000972, 2000974, 2000975, 2000977, 2000978, 2000981, 2000982, 2000983, 2000984, 2000987, 2000990, 2000991, 2000993, 2000996, 2000997, 2000998, 2001000, 2001001, 2001002, 2001004, 2001005, 2001006, 2001008, 2001011, 2001012, 2001013, 2001014, 2001017, 2001018, 2001020, 2001021, 2001023, 2001025, 2001026, 2001027, 2001028, 2001029, 2001031, 2001032, 2001034, 2001035, 2001036, 2001037, 2001038, 2001041, 2001042, 2001043, 2001044, 2001046, 2001047, 2001048, 2001049, 2001050, 2001052, 2001053, 2001054, 2001056, 2001057, 2001059, 2001060, 2001063, 2001064, 2001065, 2001067, 2001068, 2001073, 2001074, 2001075, 2001077, 2001080, 2001081, 2001085, 2001087, 2001088, 2001089, 2001090, 2001091, 2001092, 2001093, 2001095, 2001096, 2001099, 2001104, 2001106, 2001108, 2001109, 2001112, 2001113, 2001119, 2001120, 2001124, 2001125, 2001126, 2001129, 2001132, 2001135, 2001136, 2001137, 2001138, 2001139, 2001140, 2001141, 2001142, 2001143, 2001144, 2001146, 2001149, 2001150, 2001151, 2001153, 2001154, 2001156, 2001157, 2001159, 2001160, 2001161, 2001162, 2001163, 2001164, 2001165, 2001166, 2001167, 2001168, 2001169, 2001172, 2001173, 2001174, 2001176, 2001178, 2001180, 2001181, 2001184, 2001188, 2001190, 2001192, 2001196, 2001198, 2001199, 2001201, 2001203, 2001204, 2001205, 2001207, 2001210, 2001211, 2001215, 2001216, 2001217, 2001222, 2001223, 2001225, 2001226, 2001227, 2001228, 2001229, 2001231, 2001232, 2001233, 2001234, 2001236, 2001238, 2001239, 2001240, 2001242, 2001244, 2001246, 2001248, 2001250, 2001254, 2001256, 2001257, 2001258, 2001259, 2001263, 2001265, 2001266, 2001267, 2001269, 2001272, 2001273, 2001275, 2001276, 2001278, 2001279, 2001280, 2001282, 2001283, 2001284, 2001285, 2001287, 2001288, 2001289, 2001291, 2001295, 2001296, 2001297, 2001298, 2001302, 2001303, 2001304, 2001305, 2001306, 2001307, 2001309, 2001310, 2001311, 2001313, 2001316, 2001322, 2001328, 2001331, 2001333, 2001334, 2001335, 2001337, 2001338, 2001340, 2001341, 2001345, 2001346, 2001347, 2001348, 2001349, 2001353, 2001357, 2001359, 2001361, 2001362, 2001363, 2001364, 2001365, 2001370, 2001372, 2001373, 2001375, 2001377, 2001382, 2001383, 2001384, 2001385, 2001388, 2001389, 2001390, 2001391, 2001392, 2001393, 2001396, 2001398, 2001402, 2001403, 2001405, 2001407, 2001408, 2001409, 2001411, 2001413, 2001415, 2001416, 2001417, 2001418, 2001421, 2001422, 2001423, 2001424, 2001425, 2001426, 2001427, 2001428, 2001430, 2001431, 2001432, 2001434, 2001435, 2001436, 2001439, 2001441, 2001443, 2001444, 2001448, 2001450, 2001451, 2001452, 2001454, 2001456, 2001458, 2001461, 2001463, 2001464, 2001466, 2001467, 2001469, 2001470, 2001471, 2001474, 2001476, 2001477, 2001480, 2001481, 2001483, 2001484, 2001485, 2001486, 2001487, 2001490, 2001492, 2001493, 2001494, 2001499, 2001500, 2001501, 2001502, 2001504, 2001511, 2001515, 2001517, 2001518, 2001519, 2001520, 2001521, 2001524, 2001525, 2001528, 2001530, 2001532, 2001533, 2001534, 2001536, 2001537, 2001538, 2001539, 2001542, 2001543, 2001544, 2001545, 2001546, 2001549, 2001554, 2001556, 2001558, 2001560, 2001564, 2001565, 2001567, 2001568, 2001570, 2001572, 2001574, 2001576, 2001577, 2001578, 2001579, 2001580, 2001581, 2001583, 2001586, 2001587, 2001589, 2001590, 2001592, 2001593, 2001594, 2001595, 2001597, 2001598, 2001599, 2001600, 2001601, 2001608, 2001609, 2001611, 2001612, 2001614, 2001615, 2001616, 2001617, 2001619, 2001620, 2001621, 2001622, 2001624, 2001627, 2001629, 2001631, 2001634, 2001636, 2001638, 2001639, 2001640, 2001642, 2001643, 2001648, 2001649, 2001651, 2001653, 2001655, 2001656, 2001658, 2001659, 2001662, 2001664, 2001666, 2001667, 2001668, 2001669, 2001670, 2001671, 2001672, 2001674, 2001675, 2001677, 2001681, 2001683, 2001685, 2001686, 2001688, 2001689, 2001694, 2001696, 2001697, 2001699, 2001702, 2001705, 2001706, 2001711, 2001712, 2001713, 2001714, 2001716, 2001717, 2001720, 2001724, 2001725, 2001727, 2001730, 2001731, 2001737, 2001738, 2001741, 2001742, 2001743, 2001746, 2001747, 2001750, 2001751, 2001756, 2001757, 2001759, 2001760, 2001762, 2001763, 2001764, 2001765, 2001767, 2001773, 2001777, 2001780, 2001781, 2001784, 2001785, 2001786, 2001788, 2001790) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177, 2000179, 2000181, 2000183, 2000184, 2000186, 2000187, 2000188, 2000190, 2000192, 2000194, 2000196, 2000197, 2000199, 2000200, 2000202, 2000203, 2000204, 2000205, 2000207, 2000208, 2000209, 2000211, 2000214, 2000216, 2000218, 2000220, 2000221, 2000222, 2000223, 2000225, 2000227, 2000228, 2000230, 2000233, 2000236, 2000238, 2000244, 2000247, 2000248, 2000251, 2000253, 2000254, 2000255, 2000256, 2000259, 2000260, 2000261, 2000263, 2000264, 2000267, 2000268, 2000269, 2000271, 2000274, 2000276, 2000279, 2000284, 2000287, 2000288, 2000289, 2000290, 2000291, 2000292, 2000297, 2000298, 2000299, 2000300, 2000301, 2000305, 2000306, 2000307, 2000308, 2000310, 2000311, 2000312, 2000313, 2000315, 2000316, 2000317, 2000318, 2000319, 2000324, 2000327, 2000329, 2000330, 2000331, 2000332, 2000333, 2000334, 2000335, 2000336, 2000338, 2000339, 2000340, 2000342, 2000344, 2000345, 2000346, 2000347, 2000349, 2000350, 2000353, 2000355, 2000356, 2000357, 2000359, 2000362, 2000363, 2000366, 2000368, 2000374, 2000377, 2000379, 2000382, 2000383, 2000386, 2000391, 2000395, 2000398, 2000399, 2000402, 2000403, 2000404, 2000406, 2000407, 2000408, 2000411, 2000413, 2000415, 2000417, 2000420, 2000421, 2000422, 2000423, 2000425, 2000426, 2000427, 2000428, 2000429, 2000430, 2000433, 2000434, 2000435, 2000436, 2000437, 2000438, 2000440, 2000441, 2000445, 2000446, 2000447, 2000449, 2000450, 2000453, 2000454, 2000455, 2000456, 2000457, 2000458, 2000459, 2000460, 2000461, 2000464, 2000465, 2000466, 2000467, 2000468, 2000470, 2000471, 2000472, 2000473, 2000474, 2000475, 2000476, 2000477, 2000478, 2000480, 2000482, 2000484, 2000487, 2000488, 2000489, 2000492, 2000493, 2000495, 2000496, 2000498, 2000500, 2000501, 2000504, 2000505, 2000506, 2000508, 2000510, 2000511, 2000513, 2000515, 2000516, 2000517, 2000520, 2000522, 2000524, 2000528, 2000530, 2000531, 2000532, 2000533, 2000535, 2000536, 2000537, 2000540, 2000542, 2000543, 2000546, 2000547, 2000549, 2000550, 2000552, 2000553, 2000554, 2000556, 2000557, 2000559, 2000560, 2000561, 2000564, 2000566, 2000567, 2000568, 2000570, 2000571, 2000574, 2000578, 2000581, 2000582, 2000583, 2000584, 2000588, 2000589, 2000590, 2000592, 2000594, 2000595, 2000598, 2000601, 2000602, 2000604, 2000609, 2000611, 2000615, 2000616, 2000621, 2000623, 2000626, 2000632, 2000633, 2000637, 2000638, 2000640, 2000641, 2000642, 2000643, 2000650, 2000651, 2000652, 2000653, 2000656, 2000660, 2000661, 2000663, 2000664, 2000668, 2000669, 2000671, 2000673, 2000674, 2000675, 2000676, 2000677, 2000678, 2000679, 2000681, 2000683, 2000687, 2000689, 2000690, 2000691, 2000692, 2000693, 2000695, 2000696, 2000698, 2000700, 2000701, 2000702, 2000704, 2000706, 2000707, 2000709, 2000712, 2000713, 2000714, 2000715, 2000718, 2000719, 2000720, 2000721, 2000724, 2000725, 2000728, 2000730, 2000732, 2000734, 2000739, 2000744, 2000750, 2000754, 2000755, 2000757, 2000758, 2000762, 2000764, 2000766, 2000767, 2000768, 2000769, 2000775, 2000776, 2000777, 2000778, 2000779, 2000781, 2000782, 2000786, 2000787, 2000788, 2000789, 2000792, 2000794, 2000795, 2000797, 2000798, 2000802, 2000804, 2000805, 2000806, 2000807, 2000808, 2000810, 2000812, 2000814, 2000816, 2000819, 2000820, 2000821, 2000822, 2000825, 2000826, 2000830, 2000831, 2000833, 2000835, 2000836, 2000837, 2000839, 2000841, 2000842, 2000843, 2000844, 2000847, 2000848, 2000849, 2000850, 2000852, 2000853, 2000854, 2000856, 2000858, 2000859, 2000862, 2000863, 2000865, 2000869, 2000870, 2000874, 2000875, 2000876, 2000877, 2000878, 2000879, 2000880, 2000881, 2000882, 2000884, 2000885, 2000887, 2000889, 2000890, 2000892, 2000894, 2000895, 2000897, 2000900, 2000902, 2000903, 2000906, 2000907, 2000912, 2000914, 2000917, 2000922, 2000924, 2000926, 2000927, 2000929, 2000930, 2000931, 2000934, 2000937, 2000938, 2000943, 2000944, 2000945, 2000946, 2000950, 2000952, 2000953, 2000955, 2000957, 2000958, 2000959, 2000960, 2000961, 2000962, 2000964, 2000967, 2000970, 2000971, 2000972, 2000974, 2000975, 2000977, 2000978, 2000981, 2000982, 2000983, 2000984, 2000987, 2000990, 2000991, 2000993, 2000996, 2000997, 2000998, 2001000, 2001001, 2001002, 2001004, 2001005, 2001006, 2001008, 2001011, 2001012, 2001013, 2001014, 2001017, 2001018, 2001020, 2001021, 2001023, 2001025, 2001026, 2001027, 2001028, 2001029, 2001031, 2001032, 2001034, 2001035, 2001036, 2001037, 2001038, 2001041, 2001042, 2001043, 2001044, 2001046, 2001047, 2001048, 2001049, 2001050, 2001052, 2001053, 2001054, 2001056, 2001057, 2001059, 2001060, 2001063, 2001064, 2001065, 2001067, 2001068, 2001073, 2001074, 2001075, 2001077, 2001080, 2001081, 2001085, 2001087, 2001088, 2001089, 2001090, 2001091, 2001092, 2001093, 2001095, 2001096, 2001099, 2001104, 2001106, 2001108, 2001109, 2001112, 2001113, 2001119, 2001120, 2001124, 2001125, 2001126, 2001129, 2001132, 2001135, 2001136, 2001137, 2001138, 2001139, 2001140, 2001141, 2001142, 2001143, 2001144, 2001146, 2001149, 2001150, 2001151, 2001153, 2001154, 2001156, 2001157, 2001159, 2001160, 2001161, 2001162, 2001163, 2001164, 2001165, 2001166, 2001167, 2001168, 2001169, 2001172, 2001173, 2001174, 2001176, 2001178, 2001180, 2001181, 2001184, 2001188, 2001190, 2001192, 2001196, 2001198, 2001199, 2001201, 2001203, 2001204, 2001205, 2001207, 2001210, 2001211, 2001215, 2001216, 2001217, 2001222, 2001223, 2001225, 2001226, 2001227, 2001228, 2001229, 2001231, 2001232, 2001233, 2001234, 2001236, 2001238, 2001239, 2001240, 2001242, 2001244, 2001246, 2001248, 2001250, 2001254, 2001256, 2001257, 2001258, 2001259, 2001263, 2001265, 2001266, 2001267, 2001269, 2001272, 2001273, 2001275, 2001276, 2001278, 2001279, 2001280, 2001282, 2001283, 2001284, 2001285, 2001287, 2001288, 2001289, 2001291, 2001295, 2001296, 2001297, 2001298, 2001302, 2001303, 2001304, 2001305, 2001306, 2001307, 2001309, 2001310, 2001311, 2001313, 2001316, 2001322, 2001328, 2001331, 2001333, 2001334, 2001335, 2001337, 2001338, 2001340, 2001341, 2001345, 2001346, 2001347, 2001348, 2001349, 2001353, 2001357, 2001359, 2001361, 2001362, 2001363, 2001364, 2001365, 2001370, 2001372, 2001373, 2001375, 2001377, 2001382, 2001383, 2001384, 2001385, 2001388, 2001389, 2001390, 2001391, 2001392, 2001393, 2001396, 2001398, 2001402, 2001403, 2001405, 2001407, 2001408, 2001409, 2001411, 2001413, 2001415, 2001416, 2001417, 2001418, 2001421, 2001422, 2001423, 2001424, 2001425, 2001426, 2001427, 2001428, 2001430, 2001431, 2001432, 2001434, 2001435, 2001436, 2001439, 2001441, 2001443, 2001444, 2001448, 2001450, 2001451, 2001452, 2001454, 2001456, 2001458, 2001461, 2001463, 2001464, 2001466, 2001467, 2001469, 2001470, 2001471, 2001474, 2001476, 2001477, 2001480, 2001481, 2001483, 2001484, 2001485, 2001486, 2001487, 2001490, 2001492, 2001493, 2001494, 2001499, 2001500, 2001501, 2001502, 2001504, 2001511, 2001515, 2001517, 2001518, 2001519, 2001520, 2001521, 2001524, 2001525, 2001528, 2001530, 2001532, 2001533, 2001534, 2001536, 2001537, 2001538, 2001539, 2001542, 2001543, 2001544, 2001545, 2001546, 2001549, 2001554, 2001556, 2001558, 2001560, 2001564, 2001565, 2001567, 2001568, 2001570, 2001572, 2001574, 2001576, 2001577, 2001578, 2001579, 2001580, 2001581, 2001583, 2001586, 2001587, 2001589, 2001590, 2001592, 2001593, 2001594, 2001595, 2001597, 2001598, 2001599, 2001600, 2001601, 2001608, 2001609, 2001611, 2001612, 2001614, 2001615, 2001616, 2001617, 2001619, 2001620, 2001621, 2001622, 2001624, 2001627, 2001629, 2001631, 2001634, 2001636, 2001638, 2001639, 2001640, 2001642, 2001643, 2001648, 2001649, 2001651, 2001653, 2001655, 2001656, 2001658, 2001659, 2001662, 2001664, 2001666, 2001667, 2001668, 2001669, 2001670, 2001671, 2001672, 2001674, 2001675, 2001677, 2001681, 2001683, 2001685, 2001686, 2001688, 2001689, 2001694, 2001696, 2001697, 2001699, 2001702, 2001705, 2001706, 2001711, 2001712, 2001713, 2001714, 2001716, 2001717, 2001720, 2001724, 2001725, 2001727, 2001730, 2001731, 2001737, 2001738, 2001741, 2001742, 2001743, 2001746, 2001747, 2001750, 2001751, 2001756, 2001757, 2001759, 2001760, 2001762, 2001763, 2001764, 2001765, 2001767, 2001773, 2001777, 2001780, 2001781, 2001784, 2001785, 2001786, 2001788, 2001790) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177, 2000179, 2000181, 2000183, 2000184, 2000186, 2000187, 2000188, 2000190, 2000192, 2000194, 2000196, 2000197, 2000199, 2000200, 2000202, 2000203, 2000204, 2000205, 2000207, 2000208, 2000209, 2000211, 2000214, 2000216, 2000218, 2000220, 2000221, 2000222, 2000223, 2000225, 2000227, 2000228, 2000230, 2000233, 2000236, 2000238, 2000244, 2000247, 2000248, 2000251, 2000253, 2000254, 2000255, 2000256, 2000259, 2000260, 2000261, 2000263, 2000264, 2000267, 2000268, 2000269, 2000271, 2000274, 2000276, 2000279, 2000284, 2000287, 2000288, 2000289, 2000290, 2000291, 2000292, 2000297, 2000298, 2000299, 2000300, 2000301, 2000305, 2000306, 2000307, 2000308, 2000310, 2000311, 2000312, 2000313, 2000315, 2000316, 2000317, 2000318, 2000319, 2000324, 2000327, 2000329, 2000330, 2000331, 2000332, 2000333, 2000334, 2000335, 2000336, 2000338, 2000339, 2000340, 2000342, 2000344, 2000345, 2000346, 2000347, 2000349, 2000350, 2000353, 2000355, 2000356, 2000357, 2000359, 2000362, 2000363, 2000366, 2000368, 2000374, 2000377, 2000379, 2000382, 2000383, 2000386, 2000391, 2000395, 2000398, 2000399, 2000402, 2000403, 2000404, 2000406, 2000407, 2000408, 2000411, 2000413, 2000415, 2000417, 2000420, 2000421, 2000422, 2000423, 2000425, 2000426, 2000427, 2000428, 2000429, 2000430, 2000433, 2000434, 2000435, 2000436, 2000437, 2000438, 2000440, 2000441, 2000445, 2000446, 2000447, 2000449, 2000450, 2000453, 2000454, 2000455, 2000456, 2000457, 2000458, 2000459, 2000460, 2000461, 2000464, 2000465, 2000466, 2000467, 2000468, 2000470, 2000471, 2000472, 2000473, 2000474, 2000475, 2000476, 2000477, 2000478, 2000480, 2000482, 2000484, 2000487, 2000488, 2000489, 2000492, 2000493, 2000495, 2000496, 2000498, 2000500, 2000501, 2000504, 2000505, 2000506, 2000508, 2000510, 2000511, 2000513, 2000515, 2000516, 2000517, 2000520, 2000522, 2000524, 2000528, 2000530, 2000531, 2000532, 2000533, 2000535, 2000536, 2000537, 2000540, 2000542, 2000543, 2000546, 2000547, 2000549, 2000550, 2000552, 2000553, 2000554, 2000556, 2000557, 2000559, 2000560, 2000561, 2000564, 2000566, 2000567, 2000568, 2000570, 2000571, 2000574, 2000578, 2000581, 2000582, 2000583, 2000584, 2000588, 2000589, 2000590, 2000592, 2000594, 2000595, 2000598, 2000601, 2000602, 2000604, 2000609, 2000611, 2000615, 2000616, 2000621, 2000623, 2000626, 2000632, 2000633, 2000637, 2000638, 2000640, 2000641, 2000642, 2000643, 2000650, 2000651, 2000652, 2000653, 2000656, 2000660, 2000661, 2000663, 2000664, 2000668, 2000669, 2000671, 2000673, 2000674, 2000675, 2000676, 2000677, 2000678, 2000679, 2000681, 2000683, 2000687, 2000689, 2000690, 2000691, 2000692, 2000693, 2000695, 2000696, 2000698, 2000700, 2000701, 2000702, 2000704, 2000706, 2000707, 2000709, 2000712, 2000713, 2000714, 2000715, 2000718, 2000719, 2000720, 2000721, 2000724, 2000725, 2000728, 2000730, 2000732, 2000734, 2000739, 2000744, 2000750, 2000754, 2000755, 2000757, 2000758, 2000762, 2000764, 2000766, 2000767, 2000768, 2000769, 2000775, 2000776, 2000777, 2000778, 2000779, 2000781, 2000782, 2000786, 2000787, 2000788, 2000789, 2000792, 2000794, 2000795, 2000797, 2000798, 2000802, 2000804, 2000805, 2000806, 2000807, 2000808, 2000810, 2000812, 2000814, 2000816, 2000819, 2000820, 2000821, 2000822, 2000825, 2000826, 2000830, 2000831, 2000833, 2000835, 2000836, 2000837, 2000839, 2000841, 2000842, 2000843, 2000844, 2000847, 2000848, 2000849, 2000850, 2000852, 2000853, 2000854, 2000856, 2000858, 2000859, 2000862, 2000863, 2000865, 2000869, 2000870, 2000874, 2000875, 2000876, 2000877, 2000878, 2000879, 2000880, 2000881, 2000882, 2000884, 2000885, 2000887, 2000889, 2000890, 2000892, 2000894, 2000895, 2000897, 2000900, 2000902, 2000903, 2000906, 2000907, 2000912, 2000914, 2000917, 2000922, 2000924, 2000926, 2000927, 2000929, 2000930, 2000931, 2000934, 2000937, 2000938, 2000943, 2000944, 2000945, 2000946, 2000950, 2000952, 2000953, 2000955, 2000957, 2000958, 2000959, 2000960, 2000961, 2000962, 2000964, 2000967, 2000970, 2000971, 2000972, 2000974, 2000975, 2000977, 2000978, 2000981, 2000982, 2000983, 2000984, 2000987, 2000990, 2000991, 2000993, 2000996, 2000997, 2000998, 2001000, 2001001, 2001002, 2001004, 2001005, 2001006, 2001008, 2001011, 2001012, 2001013, 2001014, 2001017, 2001018, 2001020, 2001021, 2001023, 2001025, 2001026, 2001027, 2001028, 2001029, 2001031, 2001032, 2001034, 2001035, 2001036, 2001037, 2001038, 2001041, 2001042, 2001043, 2001044, 2001046, 2001047, 2001048, 2001049, 2001050, 2001052, 2001053, 2001054, 2001056, 2001057, 2001059, 2001060, 2001063, 2001064, 2001065, 2001067, 2001068, 2001073, 2001074, 2001075, 2001077, 2001080, 2001081, 2001085, 2001087, 2001088, 2001089, 2001090, 2001091, 2001092, 2001093, 2001095, 2001096, 2001099, 2001104, 2001106, 2001108, 2001109, 2001112, 2001113, 2001119, 2001120, 2001124, 2001125, 2001126, 2001129, 2001132, 2001135, 2001136, 2001137, 2001138, 2001139, 2001140, 2001141, 2001142, 2001143, 2001144, 2001146, 2001149, 2001150, 2001151, 2001153, 2001154, 2001156, 2001157, 2001159, 2001160, 2001161, 2001162, 2001163, 2001164, 2001165, 2001166, 2001167, 2001168, 2001169, 2001172, 2001173, 2001174, 2001176, 2001178, 2001180, 2001181, 2001184, 2001188, 2001190, 2001192, 2001196, 2001198, 2001199, 2001201, 2001203, 2001204, 2001205, 2001207, 2001210, 2001211, 2001215, 2001216, 2001217, 2001222, 2001223, 2001225, 2001226, 2001227, 2001228, 2001229, 2001231, 2001232, 2001233, 2001234, 2001236, 2001238, 2001239, 2001240, 2001242, 2001244, 2001246, 2001248, 2001250, 2001254, 2001256, 2001257, 2001258, 2001259, 2001263, 2001265, 2001266, 2001267, 2001269, 2001272, 2001273, 2001275, 2001276, 2001278, 2001279, 2001280, 2001282, 2001283, 2001284, 2001285, 2001287, 2001288, 2001289, 2001291, 2001295, 2001296, 2001297, 2001298, 2001302, 2001303, 2001304, 2001305, 2001306, 2001307, 2001309, 2001310, 2001311, 2001313, 2001316, 2001322, 2001328, 2001331, 2001333, 2001334, 2001335, 2001337, 2001338, 2001340, 2001341, 2001345, 2001346, 2001347, 2001348, 2001349, 2001353, 2001357, 2001359, 2001361, 2001362, 2001363, 2001364, 2001365, 2001370, 2001372, 2001373, 2001375, 2001377, 2001382, 2001383, 2001384, 2001385, 2001388, 2001389, 2001390, 2001391, 2001392, 2001393, 2001396, 2001398, 2001402, 2001403, 2001405, 2001407, 2001408, 2001409, 2001411, 2001413, 2001415, 2001416, 2001417, 2001418, 2001421, 2001422, 2001423, 2001424, 2001425, 2001426, 2001427, 2001428, 2001430, 2001431, 2001432, 2001434, 2001435, 2001436, 2001439, 2001441, 2001443, 2001444, 2001448, 2001450, 2001451, 2001452, 2001454, 2001456, 2001458, 2001461, 2001463, 2001464, 2001466, 2001467, 2001469, 2001470, 2001471, 2001474, 2001476, 2001477, 2001480, 2001481, 2001483, 2001484, 2001485, 2001486, 2001487, 2001490, 2001492, 2001493, 2001494, 2001499, 2001500, 2001501, 2001502, 2001504, 2001511, 2001515, 2001517, 2001518, 2001519, 2001520, 2001521, 2001524, 2001525, 2001528, 2001530, 2001532, 2001533, 2001534, 2001536, 2001537, 2001538, 2001539, 2001542, 2001543, 2001544, 2001545, 2001546, 2001549, 2001554, 2001556, 2001558, 2001560, 2001564, 2001565, 2001567, 2001568, 2001570, 2001572, 2001574, 2001576, 2001577, 2001578, 2001579, 2001580, 2001581, 2001583, 2001586, 2001587, 2001589, 2001590, 2001592, 2001593, 2001594, 2001595, 2001597, 2001598, 2001599, 2001600, 2001601, 2001608, 2001609, 2001611, 2001612, 2001614, 2001615, 2001616, 2001617, 2001619, 2001620, 2001621, 2001622, 2001624, 2001627, 2001629, 2001631, 2001634, 2001636, 2001638, 2001639, 2001640, 2001642, 2001643, 2001648, 2001649, 2001651, 2001653, 2001655, 2001656, 2001658, 2001659, 2001662, 2001664, 2001666, 2001667, 2001668, 2001669, 2001670, 2001671, 2001672, 2001674, 2001675, 2001677, 2001681, 2001683, 2001685, 2001686, 2001688, 2001689, 2001694, 2001696, 2001697, 2001699, 2001702, 2001705, 2001706, 2001711, 2001712, 2001713, 2001714, 2001716, 2001717, 2001720, 2001724, 2001725, 2001727, 2001730, 2001731, 2001737, 2001738, 2001741, 2001742, 2001743, 2001746, 2001747, 2001750, 2001751, 2001756, 2001757, 2001759, 2001760, 2001762, 2001763, 2001764, 2001765, 2001767, 2001773, 2001777, 2001780, 2001781, 2001784, 2001785, 2001786, 2001788, 2001790) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (1013577, 10192, 2000001, 2000005, 2000006, 2000007, 2000009, 2000011, 2000014, 2000015, 2000018, 2000019, 2000020, 2000021, 2000022, 2000023, 2000024, 2000025, 2000026, 2000028, 2000029, 2000030, 2000032, 2000034, 2000038, 2000040, 2000045, 2000048, 2000049, 2000051, 2000052, 2000054, 2000055, 2000058, 2000059, 2000061, 2000062, 2000063, 2000064, 2000069, 2000070, 2000071, 2000072, 2000074, 2000080, 2000081, 2000084, 2000085, 2000090, 2000092, 2000094, 2000095, 2000099, 2000102, 2000103, 2000109, 2000110, 2000111, 2000112, 2000113, 2000114, 2000115, 2000116, 2000117, 2000118, 2000120, 2000121, 2000122, 2000123, 2000124, 2000129, 2000131, 2000132, 2000133, 2000135, 2000136, 2000138, 2000139, 2000143, 2000145, 2000148, 2000150, 2000153, 2000155, 2000156, 2000157, 2000158, 2000159, 2000164, 2000165, 2000166, 2000167, 2000168, 2000169, 2000170, 2000171, 2000172, 2000173, 2000175, 2000177, 2000179, 2000181, 2000183, 2000184, 2000186, 2000187, 2000188, 2000190, 2000192, 2000194, 2000196, 2000197, 2000199, 2000200, 2000202, 2000203, 2000204, 2000205, 2000207, 2000208, 2000209, 2000211, 2000214, 2000216, 2000218, 2000220, 2000221, 2000222, 2000223, 2000225, 2000227, 2000228, 2000230, 2000233, 2000236, 2000238, 2000244, 2000247, 2000248, 2000251, 2000253, 2000254, 2000255, 2000256, 2000259, 2000260, 2000261, 2000263, 2000264, 2000267, 2000268, 2000269, 2000271, 2000274, 2000276, 2000279, 2000284, 2000287, 2000288, 2000289, 2000290, 2000291, 2000292, 2000297, 2000298, 2000299, 2000300, 2000301, 2000305, 2000306, 2000307, 2000308, 2000310, 2000311, 2000312, 2000313, 2000315, 2000316, 2000317, 2000318, 2000319, 2000324, 2000327, 2000329, 2000330, 2000331, 2000332, 2000333, 2000334, 2000335, 2000336, 2000338, 2000339, 2000340, 2000342, 2000344, 2000345, 2000346, 2000347, 2000349, 2000350, 2000353, 2000355, 2000356, 2000357, 2000359, 2000362, 2000363, 2000366, 2000368, 2000374, 2000377, 2000379, 2000382, 2000383, 2000386, 2000391, 2000395, 2000398, 2000399, 2000402, 2000403, 2000404, 2000406, 2000407, 2000408, 2000411, 2000413, 2000415, 2000417, 2000420, 2000421, 2000422, 2000423, 2000425, 2000426, 2000427, 2000428, 2000429, 2000430, 2000433, 2000434, 2000435, 2000436, 2000437, 2000438, 2000440, 2000441, 2000445, 2000446, 2000447, 2000449, 2000450, 2000453, 2000454, 2000455, 2000456, 2000457, 2000458, 2000459, 2000460, 2000461, 2000464, 2000465, 2000466, 2000467, 2000468, 2000470, 2000471, 2000472, 2000473, 2000474, 2000475, 2000476, 2000477, 2000478, 2000480, 2000482, 2000484, 2000487, 2000488, 2000489, 2000492, 2000493, 2000495, 2000496, 2000498, 2000500, 2000501, 2000504, 2000505, 2000506, 2000508, 2000510, 2000511, 2000513, 2000515, 2000516, 2000517, 2000520, 2000522, 2000524, 2000528, 2000530, 2000531, 2000532, 2000533, 2000535, 2000536, 2000537, 2000540, 2000542, 2000543, 2000546, 2000547, 2000549, 2000550, 2000552, 2000553, 2000554, 2000556, 2000557, 2000559, 2000560, 2000561, 2000564, 2000566, 2000567, 2000568, 2000570, 2000571, 2000574, 2000578, 2000581, 2000582, 2000583, 2000584, 2000588, 2000589, 2000590, 2000592, 2000594, 2000595, 2000598, 2000601, 2000602, 2000604, 2000609, 2000611, 2000615, 2000616, 2000621, 2000623, 2000626, 2000632, 2000633, 2000637, 2000638, 2000640, 2000641, 2000642, 2000643, 2000650, 2000651, 2000652, 2000653, 2000656, 2000660, 2000661, 2000663, 2000664, 2000668, 2000669, 2000671, 2000673, 2000674, 2000675, 2000676, 2000677, 2000678, 2000679, 2000681, 2000683, 2000687, 2000689, 2000690, 2000691, 2000692, 2000693, 2000695, 2000696, 2000698, 2000700, 2000701, 2000702, 2000704, 2000706, 2000707, 2000709, 2000712, 2000713, 2000714, 2000715, 2000718, 2000719, 2000720, 2000721, 2000724, 2000725, 2000728, 2000730, 2000732, 2000734, 2000739, 2000744, 2000750, 2000754, 2000755, 2000757, 2000758, 2000762, 2000764, 2000766, 2000767, 2000768, 2000769, 2000775, 2000776, 2000777, 2000778, 2000779, 2000781, 2000782, 2000786, 2000787, 2000788, 2000789, 2000792, 2000794, 2000795, 2000797, 2000798, 2000802, 2000804, 2000805, 2000806, 2000807, 2000808, 2000810, 2000812, 2000814, 2000816, 2000819, 2000820, 2000821, 2000822, 2000825, 2000826, 2000830, 2000831, 2000833, 2000835, 2000836, 2000837, 2000839, 2000841, 2000842, 2000843, 2000844, 2000847, 2000848, 2000849, 2000850, 2000852, 2000853, 2000854, 2000856, 2000858, 2000859, 2000862, 2000863, 2000865, 2000869, 2000870, 2000874, 2000875, 2000876, 2000877, 2000878, 2000879, 2000880, 2000881, 2000882, 2000884, 2000885, 2000887, 2000889, 2000890, 2000892, 2000894, 2000895, 2000897, 2000900, 2000902, 2000903, 2000906, 2000907, 2000912, 2000914, 2000917, 2000922, 2000924, 2000926, 2000927, 2000929, 2000930, 2000931, 2000934, 2000937, 2000938, 2000943, 2000944, 2000945, 2000946, 2000950, 2000952, 2000953, 2000955, 2000957, 2000958, 2000959, 2000960, 2000961, 2000962, 2000964, 2000967, 2000970, 2000971, 2000972, 2000974, 2000975, 2000977, 2000978, 2000981, 2000982, 2000983, 2000984, 2000987, 2000990, 2000991, 2000993, 2000996, 2000997, 2000998, 2001000, 2001001, 2001002, 2001004, 2001005, 2001006, 2001008, 2001011, 2001012, 2001013, 2001014, 2001017, 2001018, 2001020, 2001021, 2001023, 2001025, 2001026, 2001027, 2001028, 2001029, 2001031, 2001032, 2001034, 2001035, 2001036, 2001037, 2001038, 2001041, 2001042, 2001043, 2001044, 2001046, 2001047, 2001048, 2001049, 2001050, 2001052, 2001053, 2001054, 2001056, 2001057, 2001059, 2001060, 2001063, 2001064, 2001065, 2001067, 2001068, 2001073, 2001074, 2001075, 2001077, 2001080, 2001081, 2001085, 2001087, 2001088, 2001089, 2001090, 2001091, 2001092, 2001093, 2001095, 2001096, 2001099, 2001104, 2001106, 2001108, 2001109, 2001112, 2001113, 2001119, 2001120, 2001124, 2001125, 2001126, 2001129, 2001132, 2001135, 2001136, 2001137, 2001138, 2001139, 2001140, 2001141, 2001142, 2001143, 2001144, 2001146, 2001149, 2001150, 2001151, 2001153, 2001154, 2001156, 2001157, 2001159, 2001160, 2001161, 2001162, 2001163, 2001164, 2001165, 2001166, 2001167, 2001168, 2001169, 2001172, 2001173, 2001174, 2001176, 2001178, 2001180, 2001181, 2001184, 2001188, 2001190, 2001192, 2001196, 2001198, 2001199, 2001201, 2001203, 2001204, 2001205, 2001207, 2001210, 2001211, 2001215, 2001216, 2001217, 2001222, 2001223, 2001225, 2001226, 2001227, 2001228, 2001229, 2001231, 2001232, 2001233, 2001234, 2001236, 2001238, 2001239, 2001240, 2001242, 2001244, 2001246, 2001248, 2001250, 2001254, 2001256, 2001257, 2001258, 2001259, 2001263, 2001265, 2001266, 2001267, 2001269, 2001272, 2001273, 2001275, 2001276, 2001278, 2001279, 2001280, 2001282, 2001283, 2001284, 2001285, 2001287, 2001288, 2001289, 2001291, 2001295, 2001296, 2001297, 2001298, 2001302, 2001303, 2001304, 2001305, 2001306, 2001307, 2001309, 2001310, 2001311, 2001313, 2001316, 2001322, 2001328, 2001331, 2001333, 2001334, 2001335, 2001337, 2001338, 2001340, 2001341, 2001345, 2001346, 2001347, 2001348, 2001349, 2001353, 2001357, 2001359, 2001361, 2001362, 2001363, 2001364, 2001365, 2001370, 2001372, 2001373, 2001375, 2001377, 2001382, 2001383, 2001384, 2001385, 2001388, 2001389, 2001390, 2001391, 2001392, 2001393, 2001396, 2001398, 2001402, 2001403, 2001405, 2001407, 2001408, 2001409, 2001411, 2001413, 2001415, 2001416, 2001417, 2001418, 2001421, 2001422, 2001423, 2001424, 2001425, 2001426, 2001427, 2001428, 2001430, 2001431, 2001432, 2001434, 2001435, 2001436, 2001439, 2001441, 2001443, 2001444, 2001448, 2001450, 2001451, 2001452, 2001454, 2001456, 2001458, 2001461, 2001463, 2001464, 2001466, 2001467, 2001469, 2001470, 2001471, 2001474, 2001476, 2001477, 2001480, 2001481, 2001483, 2001484, 2001485, 2001486, 2001487, 2001490, 2001492, 2001493, 2001494, 2001499, 2001500, 2001501, 2001502, 2001504, 2001511, 2001515, 2001517, 2001518, 2001519, 2001520, 2001521, 2001524, 2001525, 2001528, 2001530, 2001532, 2001533, 2001534, 2001536, 2001537, 2001538, 2001539, 2001542, 2001543, 2001544, 2001545, 2001546, 2001549, 2001554, 2001556, 2001558, 2001560, 2001564, 2001565, 2001567, 2001568, 2001570, 2001572, 2001574, 2001576, 2001577, 2001578, 2001579, 2001580, 2001581, 2001583, 2001586, 2001587, 2001589, 2001590, 2001592, 2001593, 2001594, 2001595, 2001597, 2001598, 2001599, 2001600, 2001601, 2001608, 2001609, 2001611, 2001612, 2001614, 2001615, 2001616, 2001617, 2001619, 2001620, 2001621, 2001622, 2001624, 2001627, 2001629, 2001631, 2001634, 2001636, 2001638, 2001639, 2001640, 2001642, 2001643, 2001648, 2001649, 2001651, 2001653, 2001655, 2001656, 2001658, 2001659, 2001662, 2001664, 2001666, 2001667, 2001668, 2001669, 2001670, 2001671, 2001672, 2001674, 2001675, 2001677, 2001681, 2001683, 2001685, 2001686, 2001688, 2001689, 2001694, 2001696, 2001697, 2001699, 2001702, 2001705, 2001706, 2001711, 2001712, 2001713, 2001714, 2001716, 2001717, 2001720, 2001724, 2001725, 2001727, 2001730, 2001731, 2001737, 2001738, 2001741, 2001742, 2001743, 2001746, 2001747, 2001750, 2001751, 2001756, 2001757, 2001759, 2001760, 2001762, 2001763, 2001764, 2001765, 2001767, 2001773, 2001777, 2001780, 2001781, 2001784, 2001785, 2001786, 2001788, 2001790) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE (rating >= 5) ORDER BY movie."added_at" ASC, movie.id ASC LIMIT 100
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (2002768, 2001880, 2001043, 2004560, 2005030, 2003871, 2004835, 2003367, 2002608, 2000052, 2000402, 2004227, 2004438, 2003796, 2005720, 2002828, 2004141, 2005855, 2003193, 2002248, 2002670, 2003137, 2001065, 2004735, 2003351, 2004593, 2003451, 2000423, 2000990, 2001034, 2001164, 2005754, 2003194, 2002693, 2001047, 2002204, 2005841, 2003487, 2002854, 2004367, 2001149, 2001108, 2003545, 2000020, 2003368, 2002939, 2001192, 2003469, 2004598, 2004603, 2001304, 2002225, 2000103, 2003310, 2001624, 2002600, 2005219, 2001418, 2004355, 2001439, 2003908, 2004256, 2002024, 2005306, 2001622, 2000029, 2004913, 2004933, 2001993, 2001542, 2004874, 2005250, 2003731, 2001328, 2005104, 2003727, 2004358, 2001222, 2004217, 2002762, 2002732, 2005866, 2005766, 2001631, 2002887, 2005878, 2005692, 2001858, 2004524, 2000349, 2002366, 2004839, 2005716, 2001556, 2005188, 2001161, 2003969, 2001611, 2003385, 2001287) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (2002768, 2001880, 2001043, 2004560, 2005030, 2003871, 2004835, 2003367, 2002608, 2000052, 2000402, 2004227, 2004438, 2003796, 2005720, 2002828, 2004141, 2005855, 2003193, 2002248, 2002670, 2003137, 2001065, 2004735, 2003351, 2004593, 2003451, 2000423, 2000990, 2001034, 2001164, 2005754, 2003194, 2002693, 2001047, 2002204, 2005841, 2003487, 2002854, 2004367, 2001149, 2001108, 2003545, 2000020, 2003368, 2002939, 2001192, 2003469, 2004598, 2004603, 2001304, 2002225, 2000103, 2003310, 2001624, 2002600, 2005219, 2001418, 2004355, 2001439, 2003908, 2004256, 2002024, 2005306, 2001622, 2000029, 2004913, 2004933, 2001993, 2001542, 2004874, 2005250, 2003731, 2001328, 2005104, 2003727, 2004358, 2001222, 2004217, 2002762, 2002732, 2005866, 2005766, 2001631, 2002887, 2005878, 2005692, 2001858, 2004524, 2000349, 2002366, 2004839, 2005716, 2001556, 2005188, 2001161, 2003969, 2001611, 2003385, 2001287) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (2002768, 2001880, 2001043, 2004560, 2005030, 2003871, 2004835, 2003367, 2002608, 2000052, 2000402, 2004227, 2004438, 2003796, 2005720, 2002828, 2004141, 2005855, 2003193, 2002248, 2002670, 2003137, 2001065, 2004735, 2003351, 2004593, 2003451, 2000423, 2000990, 2001034, 2001164, 2005754, 2003194, 2002693, 2001047, 2002204, 2005841, 2003487, 2002854, 2004367, 2001149, 2001108, 2003545, 2000020, 2003368, 2002939, 2001192, 2003469, 2004598, 2004603, 2001304, 2002225, 2000103, 2003310, 2001624, 2002600, 2005219, 2001418, 2004355, 2001439, 2003908, 2004256, 2002024, 2005306, 2001622, 2000029, 2004913, 2004933, 2001993, 2001542, 2004874, 2005250, 2003731, 2001328, 2005104, 2003727, 2004358, 2001222, 2004217, 2002762, 2002732, 2005866, 2005766, 2001631, 2002887, 2005878, 2005692, 2001858, 2004524, 2000349, 2002366, 2004839, 2005716, 2001556, 2005188, 2001161, 2003969, 2001611, 2003385, 2001287) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (2002768, 2001880, 2001043, 2004560, 2005030, 2003871, 2004835, 2003367, 2002608, 2000052, 2000402, 2004227, 2004438, 2003796, 2005720, 2002828, 2004141, 2005855, 2003193, 2002248, 2002670, 2003137, 2001065, 2004735, 2003351, 2004593, 2003451, 2000423, 2000990, 2001034, 2001164, 2005754, 2003194, 2002693, 2001047, 2002204, 2005841, 2003487, 2002854, 2004367, 2001149, 2001108, 2003545, 2000020, 2003368, 2002939, 2001192, 2003469, 2004598, 2004603, 2001304, 2002225, 2000103, 2003310, 2001624, 2002600, 2005219, 2001418, 2004355, 2001439, 2003908, 2004256, 2002024, 2005306, 2001622, 2000029, 2004913, 2004933, 2001993, 2001542, 2004874, 2005250, 2003731, 2001328, 2005104, 2003727, 2004358, 2001222, 2004217, 2002762, 2002732, 2005866, 2005766, 2001631, 2002887, 2005878, 2005692, 2001858, 2004524, 2000349, 2002366, 2004839, 2005716, 2001556, 2005188, 2001161, 2003969, 2001611, 2003385, 2001287) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
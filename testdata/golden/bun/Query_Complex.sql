@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE ((EXISTS (SELECT 1 FROM "movie_directors" JOIN people ON people.id = movie_directors.person_id WHERE (movie_directors.movie_id = movie.id) AND (INSTR(people.name, 'Affleck') > 0)) OR EXISTS (SELECT 1 FROM "movie_actors" JOIN people ON people.id = movie_actors.person_id WHERE (movie_actors.movie_id = movie.id) AND (INSTR(people.name, 'Affleck') > 0)))) AND (EXISTS (SELECT * FROM movie_genres JOIN genres ON genres.id = movie_genres.genre_id WHERE (movie_genres.movie_id = movie.id) AND (genres.name = 'Drama'))) AND (EXISTS (SELECT * FROM movie_countries JOIN countries ON countries.id = movie_countries.country_id WHERE (movie_countries.movie_id = movie.id) AND (countries.name = 'United Kingdom'))) AND (added_at < '2025-01-01 00:00:00+00:00') AND (added_at > '2020-01-01 00:00:00+00:00') AND (rating >= 4) AND (rating <= 8) ORDER BY movie."title" ASC, movie.id ASC LIMIT 1
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (505225) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (505225) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (505225) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (505225) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
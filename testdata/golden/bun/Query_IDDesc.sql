@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" ORDER BY movie."id" DESC, movie.id DESC LIMIT 100
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (2005999, 2005998, 2005997, 2005996, 2005995, 2005994, 2005993, 2005992, 2005991, 2005990, 2005989, 2005988, 2005987, 2005986, 2005985, 2005984, 2005983, 2005982, 2005981, 2005980, 2005979, 2005978, 2005977, 2005976, 2005975, 2005974, 2005973, 2005972, 2005971, 2005970, 2005969, 2005968, 2005967, 2005966, 2005965, 2005964, 2005963, 2005962, 2005961, 2005960, 2005959, 2005958, 2005957, 2005956, 2005955, 2005954, 2005953, 2005952, 2005951, 2005950, 2005949, 2005948, 2005947, 2005946, 2005945, 2005944, 2005943, 2005942, 2005941, 2005940, 2005939, 2005938, 2005937, 2005936, 2005935, 2005934, 2005933, 2005932, 2005931, 2005930, 2005929, 2005928, 2005927, 2005926, 2005925, 2005924, 2005923, 2005922, 2005921, 2005920, 2005919, 2005918, 2005917, 2005916, 2005915, 2005914, 2005913, 2005912, 2005911, 2005910, 2005909, 2005908, 2005907, 2005906, 2005905, 2005904, 2005903, 2005902, 2005901, 2005900) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (2005999, 2005998, 2005997, 2005996, 2005995, 2005994, 2005993, 2005992, 2005991, 2005990, 2005989, 2005988, 2005987, 2005986, 2005985, 2005984, 2005983, 2005982, 2005981, 2005980, 2005979, 2005978, 2005977, 2005976, 2005975, 2005974, 2005973, 2005972, 2005971, 2005970, 2005969, 2005968, 2005967, 2005966, 2005965, 2005964, 2005963, 2005962, 2005961, 2005960, 2005959, 2005958, 2005957, 2005956, 2005955, 2005954, 2005953, 2005952, 2005951, 2005950, 2005949, 2005948, 2005947, 2005946, 2005945, 2005944, 2005943, 2005942, 2005941, 2005940, 2005939, 2005938, 2005937, 2005936, 2005935, 2005934, 2005933, 2005932, 2005931, 2005930, 2005929, 2005928, 2005927, 2005926, 2005925, 2005924, 2005923, 2005922, 2005921, 2005920, 2005919, 2005918, 2005917, 2005916, 2005915, 2005914, 2005913, 2005912, 2005911, 2005910, 2005909, 2005908, 2005907, 2005906, 2005905, 2005904, 2005903, 2005902, 2005901, 2005900) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (2005999, 2005998, 2005997, 2005996, 2005995, 2005994, 2005993, 2005992, 2005991, 2005990, 2005989, 2005988, 2005987, 2005986, 2005985, 2005984, 2005983, 2005982, 2005981, 2005980, 2005979, 2005978, 2005977, 2005976, 2005975, 2005974, 2005973, 2005972, 2005971, 2005970, 2005969, 2005968, 2005967, 2005966, 2005965, 2005964, 2005963, 2005962, 2005961, 2005960, 2005959, 2005958, 2005957, 2005956, 2005955, 2005954, 2005953, 2005952, 2005951, 2005950, 2005949, 2005948, 2005947, 2005946, 2005945, 2005944, 2005943, 2005942, 2005941, 2005940, 2005939, 2005938, 2005937, 2005936, 2005935, 2005934, 2005933, 2005932, 2005931, 2005930, 2005929, 2005928, 2005927, 2005926, 2005925, 2005924, 2005923, 2005922, 2005921, 2005920, 2005919, 2005918, 2005917, 2005916, 2005915, 2005914, 2005913, 2005912, 2005911, 2005910, 2005909, 2005908, 2005907, 2005906, 2005905, 2005904, 2005903, 2005902, 2005901, 2005900) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (2005999, 2005998, 2005997, 2005996, 2005995, 2005994, 2005993, 2005992, 2005991, 2005990, 2005989, 2005988, 2005987, 2005986, 2005985, 2005984, 2005983, 2005982, 2005981, 2005980, 2005979, 2005978, 2005977, 2005976, 2005975, 2005974, 2005973, 2005972, 2005971, 2005970, 2005969, 2005968, 2005967, 2005966, 2005965, 2005964, 2005963, 2005962, 2005961, 2005960, 2005959, 2005958, 2005957, 2005956, 2005955, 2005954, 2005953, 2005952, 2005951, 2005950, 2005949, 2005948, 2005947, 2005946, 2005945, 2005944, 2005943, 2005942, 2005941, 2005940, 2005939, 2005938, 2005937, 2005936, 2005935, 2005934, 2005933, 2005932, 2005931, 2005930, 2005929, 2005928, 2005927, 2005926, 2005925, 2005924, 2005923, 2005922, 2005921, 2005920, 2005919, 2005918, 2005917, 2005916, 2005915, 2005914, 2005913, 2005912, 2005911, 2005910, 2005909, 2005908, 2005907, 2005906, 2005905, 2005904, 2005903, 2005902, 2005901, 2005900) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
@@ -0,0 +1,10 @@
+SELECT "movie"."id", "movie"."title", "movie"."added_at", "movie"."rating" FROM "movies" AS "movie" WHERE (rating >= 5) ORDER BY movie."rating" DESC, movie.id DESC LIMIT 100
+
+SELECT "movie_director"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_directors" AS "movie_director" ON ("movie_director"."movie_id") IN (2005983, 2005884, 2005869, 2005834, 2005722, 2005620, 2005137, 2004814, 2004809, 2004686, 2004375, 2004051, 2004041, 2003768, 2003639, 2003508, 2003125, 2002965, 2002720, 2002578, 2002565, 2002394, 2002267, 2001974, 2001810, 2001542, 2001424, 2001353, 2001349, 2001266, 2001217, 2001161, 2001092, 2000889, 2000859, 2000306, 2000103, 2005960, 2005949, 2005773, 2005666, 2005573, 2005377, 2005213, 2005201, 2005172, 2005093, 2004987, 2004983, 2004872, 2004633, 2004609, 2004593, 2004521, 2004468, 2004434, 2004382, 2004344, 2004154, 2004061, 2003842, 2003773, 2003735, 2003715, 2003608, 2003588, 2003480, 2003401, 2003394, 2003327, 2003264, 2003221, 2003218, 2003069, 2002843, 2002836, 2002827, 2002801, 2002534, 2002470, 2002468, 2002317, 2001926, 2001924, 2001839, 2001614, 2001601, 2001469, 2001238, 2001153, 2001129, 2001081, 2001050, 2000978, 2000955, 2000875, 2000677, 2000554, 2000421, 2000417) WHERE ("person"."id" = "movie_director"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_actor"."movie_id", "person"."id", "person"."name" FROM "people" AS "person" JOIN "movie_actors" AS "movie_actor" ON ("movie_actor"."movie_id") IN (2005983, 2005884, 2005869, 2005834, 2005722, 2005620, 2005137, 2004814, 2004809, 2004686, 2004375, 2004051, 2004041, 2003768, 2003639, 2003508, 2003125, 2002965, 2002720, 2002578, 2002565, 2002394, 2002267, 2001974, 2001810, 2001542, 2001424, 2001353, 2001349, 2001266, 2001217, 2001161, 2001092, 2000889, 2000859, 2000306, 2000103, 2005960, 2005949, 2005773, 2005666, 2005573, 2005377, 2005213, 2005201, 2005172, 2005093, 2004987, 2004983, 2004872, 2004633, 2004609, 2004593, 2004521, 2004468, 2004434, 2004382, 2004344, 2004154, 2004061, 2003842, 2003773, 2003735, 2003715, 2003608, 2003588, 2003480, 2003401, 2003394, 2003327, 2003264, 2003221, 2003218, 2003069, 2002843, 2002836, 2002827, 2002801, 2002534, 2002470, 2002468, 2002317, 2001926, 2001924, 2001839, 2001614, 2001601, 2001469, 2001238, 2001153, 2001129, 2001081, 2001050, 2000978, 2000955, 2000875, 2000677, 2000554, 2000421, 2000417) WHERE ("person"."id" = "movie_actor"."person_id") ORDER BY "name" ASC
+
+SELECT "movie_country"."movie_id", "country"."id", "country"."name" FROM "countries" AS "country" JOIN "movie_countries" AS "movie_country" ON ("movie_country"."movie_id") IN (2005983, 2005884, 2005869, 2005834, 2005722, 2005620, 2005137, 2004814, 2004809, 2004686, 2004375, 2004051, 2004041, 2003768, 2003639, 2003508, 2003125, 2002965, 2002720, 2002578, 2002565, 2002394, 2002267, 2001974, 2001810, 2001542, 2001424, 2001353, 2001349, 2001266, 2001217, 2001161, 2001092, 2000889, 2000859, 2000306, 2000103, 2005960, 2005949, 2005773, 2005666, 2005573, 2005377, 2005213, 2005201, 2005172, 2005093, 2004987, 2004983, 2004872, 2004633, 2004609, 2004593, 2004521, 2004468, 2004434, 2004382, 2004344, 2004154, 2004061, 2003842, 2003773, 2003735, 2003715, 2003608, 2003588, 2003480, 2003401, 2003394, 2003327, 2003264, 2003221, 2003218, 2003069, 2002843, 2002836, 2002827, 2002801, 2002534, 2002470, 2002468, 2002317, 2001926, 2001924, 2001839, 2001614, 2001601, 2001469, 2001238, 2001153, 2001129, 2001081, 2001050, 2000978, 2000955, 2000875, 2000677, 2000554, 2000421, 2000417) WHERE ("country"."id" = "movie_country"."country_id") ORDER BY "name" ASC
+
+SELECT "movie_genre"."movie_id", "genre"."id", "genre"."name" FROM "genres" AS "genre" JOIN "movie_genres" AS "movie_genre" ON ("movie_genre"."movie_id") IN (2005983, 2005884, 2005869, 2005834, 2005722, 2005620, 2005137, 2004814, 2004809, 2004686, 2004375, 2004051, 2004041, 2003768, 2003639, 2003508, 2003125, 2002965, 2002720, 2002578, 2002565, 2002394, 2002267, 2001974, 2001810, 2001542, 2001424, 2001353, 2001349, 2001266, 2001217, 2001161, 2001092, 2000889, 2000859, 2000306, 2000103, 2005960, 2005949, 2005773, 2005666, 2005573, 2005377, 2005213, 2005201, 2005172, 2005093, 2004987, 2004983, 2004872, 2004633, 2004609, 2004593, 2004521, 2004468, 2004434, 2004382, 2004344, 2004154, 2004061, 2003842, 2003773, 2003735, 2003715, 2003608, 2003588, 2003480, 2003401, 2003394, 2003327, 2003264, 2003221, 2003218, 2003069, 2002843, 2002836, 2002827, 2002801, 2002534, 2002470, 2002468, 2002317, 2001926, 2001924, 2001839, 2001614, 2001601, 2001469, 2001238, 2001153, 2001129, 2001081, 2001050, 2000978, 2000955, 2000875, 2000677, 2000554, 2000421, 2000417) WHERE ("genre"."id" = "movie_genre"."genre_id") ORDER BY "name" ASC
+
@@ -0,0 +1,10 @@
+SELECT `movies`.`id`, `movies`.`title`, `movies`.`added_at`, `movies`.`rating` FROM `movies` WHERE `movies`.`rating` >= ? ORDER BY `movies`.`title`, `movies`.`id` LIMIT 10
+
+SELECT `t1`.`movie_id`, `people`.`id`, `people`.`name` FROM `people` JOIN `movie_directors` AS `t1` ON `people`.`id` = `t1`.`person_id` WHERE `t1`.`movie_id` IN (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ORDER BY `people`.`name`
+
+SELECT `t1`.`movie_id`, `people`.`id`, `people`.`name` FROM `people` JOIN `movie_actors` AS `t1` ON `people`.`id` = `t1`.`person_id` WHERE `t1`.`movie_id` IN (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ORDER BY `people`.`name`
+
+SELECT `t1`.`movie_id`, `countries`.`id`, `countries`.`name` FROM `countries` JOIN `movie_countries` AS `t1` ON `countries`.`id` = `t1`.`country_id` WHERE `t1`.`movie_id` IN (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ORDER BY `countries`.`name`
+
+SELECT `t1`.`movie_id`, `genres`.`id`, `genres`.`name` FROM `genres` JOIN `movie_genres` AS `t1` ON `genres`.`id` = `t1`.`genre_id` WHERE `t1`.`movie_id` IN (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ORDER BY `genres`.`name`
+
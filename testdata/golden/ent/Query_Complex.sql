@@ -0,0 +1,10 @@
+SELECT `movies`.`id`, `movies`.`title`, `movies`.`added_at`, `movies`.`rating` FROM `movies` WHERE ((((((`movies`.`id` IN (SELECT `movie_directors`.`movie_id` FROM `movie_directors` JOIN `people` AS `t1` ON `movie_directors`.`person_id` = `t1`.`id` WHERE INSTR(`t1`.`name`, ?) > 0) OR `movies`.`id` IN (SELECT `movie_actors`.`movie_id` FROM `movie_actors` JOIN `people` AS `t1` ON `movie_actors`.`person_id` = `t1`.`id` WHERE INSTR(`t1`.`name`, ?) > 0)) AND `movies`.`id` IN (SELECT `movie_genres`.`movie_id` FROM `movie_genres` JOIN `genres` AS `t1` ON `movie_genres`.`genre_id` = `t1`.`id` WHERE `t1`.`name` = ?)) AND `movies`.`id` IN (SELECT `movie_countries`.`movie_id` FROM `movie_countries` JOIN `countries` AS `t1` ON `movie_countries`.`country_id` = `t1`.`id` WHERE `t1`.`name` = ?)) AND `movies`.`added_at` > ?) AND `movies`.`added_at` < ?) AND `movies`.`rating` >= ?) AND `movies`.`rating` <= ? ORDER BY `movies`.`title`, `movies`.`id` LIMIT 1
+
+SELECT `t1`.`movie_id`, `people`.`id`, `people`.`name` FROM `people` JOIN `movie_directors` AS `t1` ON `people`.`id` = `t1`.`person_id` WHERE `t1`.`movie_id` IN (?) ORDER BY `people`.`name`
+
+SELECT `t1`.`movie_id`, `people`.`id`, `people`.`name` FROM `people` JOIN `movie_actors` AS `t1` ON `people`.`id` = `t1`.`person_id` WHERE `t1`.`movie_id` IN (?) ORDER BY `people`.`name`
+
+SELECT `t1`.`movie_id`, `countries`.`id`, `countries`.`name` FROM `countries` JOIN `movie_countries` AS `t1` ON `countries`.`id` = `t1`.`country_id` WHERE `t1`.`movie_id` IN (?) ORDER BY `countries`.`name`
+
+SELECT `t1`.`movie_id`, `genres`.`id`, `genres`.`name` FROM `genres` JOIN `movie_genres` AS `t1` ON `genres`.`id` = `t1`.`genre_id` WHERE `t1`.`movie_id` IN (?) ORDER BY `genres`.`name`
+
@@ -0,0 +1,18 @@
+SELECT DISTINCT movies.* FROM `movies` WHERE rating >= ? ORDER BY movies.title ASC,movies.id ASC LIMIT 1
+
+SELECT * FROM `movie_actors` WHERE `movie_actors`.`movie_id` = ?
+
+SELECT * FROM `people` WHERE `people`.`id` IN (?,?,?,?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_countries` WHERE `movie_countries`.`movie_id` = ?
+
+SELECT * FROM `countries` WHERE `countries`.`id` = ? ORDER BY name ASC
+
+SELECT * FROM `movie_directors` WHERE `movie_directors`.`movie_id` = ?
+
+SELECT * FROM `people` WHERE `people`.`id` = ? ORDER BY name ASC
+
+SELECT * FROM `movie_genres` WHERE `movie_genres`.`movie_id` = ?
+
+SELECT * FROM `genres` WHERE `genres`.`id` = ? ORDER BY name ASC
+
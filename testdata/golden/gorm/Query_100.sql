@@ -0,0 +1,18 @@
+SELECT DISTINCT movies.* FROM `movies` WHERE rating >= ? ORDER BY movies.title ASC,movies.id ASC LIMIT 100
+
+SELECT * FROM `movie_actors` WHERE `movie_actors`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+
+SELECT * FROM `people` WHERE `people`.`id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_countries` WHERE `movie_countries`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+
+SELECT * FROM `countries` WHERE `countries`.`id` IN (?,?,?,?,?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_directors` WHERE `movie_directors`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+
+SELECT * FROM `people` WHERE `people`.`id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_genres` WHERE `movie_genres`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+
+SELECT * FROM `genres` WHERE `genres`.`id` IN (?,?,?,?,?,?,?,?,?,?,?) ORDER BY name ASC
+
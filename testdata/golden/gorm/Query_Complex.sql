@@ -0,0 +1,18 @@
+SELECT DISTINCT movies.* FROM `movies` LEFT JOIN movie_directors md ON md.movie_id = movies.id LEFT JOIN people d ON d.id = md.person_id LEFT JOIN movie_actors ma ON ma.movie_id = movies.id LEFT JOIN people a ON a.id = ma.person_id JOIN movie_genres mg ON mg.movie_id = movies.id JOIN genres g ON g.id = mg.genre_id JOIN movie_countries mc ON mc.movie_id = movies.id JOIN countries c ON c.id = mc.country_id WHERE (INSTR(d.name, ?) > 0 OR INSTR(a.name, ?) > 0) AND g.name = ? AND c.name = ? AND added_at < ? AND added_at > ? AND rating >= ? AND rating <= ? ORDER BY movies.title ASC,movies.id ASC LIMIT 1
+
+SELECT * FROM `movie_actors` WHERE `movie_actors`.`movie_id` = ?
+
+SELECT * FROM `people` WHERE `people`.`id` IN (?,?,?,?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_countries` WHERE `movie_countries`.`movie_id` = ?
+
+SELECT * FROM `countries` WHERE `countries`.`id` IN (?,?) ORDER BY name ASC
+
+SELECT * FROM `movie_directors` WHERE `movie_directors`.`movie_id` = ?
+
+SELECT * FROM `people` WHERE `people`.`id` = ? ORDER BY name ASC
+
+SELECT * FROM `movie_genres` WHERE `movie_genres`.`movie_id` = ?
+
+SELECT * FROM `genres` WHERE `genres`.`id` IN (?,?) ORDER BY name ASC
+
@@ -0,0 +1,10 @@
+SELECT "movies".* FROM "movies" WHERE ("movies"."rating" >= ?) ORDER BY movies.title ASC, movies.id ASC LIMIT 1;
+
+SELECT "people"."id", "people"."name", "a"."movie_id" FROM "people" INNER JOIN "movie_directors" as "a" on "people"."id" = "a"."person_id" WHERE ("a"."movie_id" IN (?)) ORDER BY people.name;
+
+SELECT "people"."id", "people"."name", "a"."movie_id" FROM "people" INNER JOIN "movie_actors" as "a" on "people"."id" = "a"."person_id" WHERE ("a"."movie_id" IN (?)) ORDER BY people.name;
+
+SELECT "countries"."id", "countries"."name", "a"."movie_id" FROM "countries" INNER JOIN "movie_countries" as "a" on "countries"."id" = "a"."country_id" WHERE ("a"."movie_id" IN (?)) ORDER BY countries.name;
+
+SELECT "genres"."id", "genres"."name", "a"."movie_id" FROM "genres" INNER JOIN "movie_genres" as "a" on "genres"."id" = "a"."genre_id" WHERE ("a"."movie_id" IN (?)) ORDER BY genres.name;
+
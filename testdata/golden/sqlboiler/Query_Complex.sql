@@ -0,0 +1,27 @@
+SELECT "movies".* FROM "movies" WHERE (EXISTS (
+				SELECT 1 FROM movie_directors
+				JOIN people ON people.id = movie_directors.person_id
+				WHERE movie_directors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)
+			OR EXISTS (
+				SELECT 1 FROM movie_actors
+				JOIN people ON people.id = movie_actors.person_id
+				WHERE movie_actors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)) AND (EXISTS (
+			SELECT 1 FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id AND genres.name = ?
+		)) AND (EXISTS (
+			SELECT 1 FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id AND countries.name = ?
+		)) AND ("movies"."added_at" < ?) AND ("movies"."added_at" > ?) AND ("movies"."rating" >= ?) AND ("movies"."rating" <= ?) ORDER BY movies.title ASC, movies.id ASC LIMIT 1;
+
+SELECT "people"."id", "people"."name", "a"."movie_id" FROM "people" INNER JOIN "movie_directors" as "a" on "people"."id" = "a"."person_id" WHERE ("a"."movie_id" IN (?)) ORDER BY people.name;
+
+SELECT "people"."id", "people"."name", "a"."movie_id" FROM "people" INNER JOIN "movie_actors" as "a" on "people"."id" = "a"."person_id" WHERE ("a"."movie_id" IN (?)) ORDER BY people.name;
+
+SELECT "countries"."id", "countries"."name", "a"."movie_id" FROM "countries" INNER JOIN "movie_countries" as "a" on "countries"."id" = "a"."country_id" WHERE ("a"."movie_id" IN (?)) ORDER BY countries.name;
+
+SELECT "genres"."id", "genres"."name", "a"."movie_id" FROM "genres" INNER JOIN "movie_genres" as "a" on "genres"."id" = "a"."genre_id" WHERE ("a"."movie_id" IN (?)) ORDER BY genres.name;
+
@@ -0,0 +1,125 @@
+-- name: QueryMovies :many
+SELECT
+    movies.id,
+    movies.title,
+    movies.added_at,
+    movies.rating,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT people.name
+            FROM movie_directors
+            JOIN people ON people.id = movie_directors.person_id
+            WHERE movie_directors.movie_id = movies.id
+            ORDER BY people.name ASC
+        )
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT people.name
+            FROM movie_actors
+            JOIN people ON people.id = movie_actors.person_id
+            WHERE movie_actors.movie_id = movies.id
+            ORDER BY people.name ASC
+        )
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT countries.name
+            FROM movie_countries
+            JOIN countries ON countries.id = movie_countries.country_id
+            WHERE movie_countries.movie_id = movies.id
+            ORDER BY countries.name ASC
+        )
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT genres.name
+            FROM movie_genres
+            JOIN genres ON genres.id = movie_genres.genre_id
+            WHERE movie_genres.movie_id = movies.id
+            ORDER BY genres.name
+        )
+    ) AS TEXT) AS genres
+FROM movies
+WHERE
+    (?1 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_directors
+        JOIN people ON people.id = movie_directors.person_id
+        WHERE movie_directors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    )
+    OR EXISTS (
+        SELECT 1
+        FROM movie_actors
+        JOIN people ON people.id = movie_actors.person_id
+        WHERE movie_actors.movie_id = movies.id
+        AND INSTR(people.name, ?1) > 0
+    ))
+    AND (?2 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_genres
+        JOIN genres ON genres.id = movie_genres.genre_id
+        WHERE movie_genres.movie_id = movies.id
+        AND genres.name = ?2
+    ))
+    AND (?3 = '' OR EXISTS (
+        SELECT 1
+        FROM movie_countries
+        JOIN countries ON countries.id = movie_countries.country_id
+        WHERE movie_countries.movie_id = movies.id
+        AND countries.name = ?3
+    ))
+    AND (?4 IS NULL OR movies.added_at > ?4)
+    AND (?5 IS NULL OR movies.added_at < ?5)
+    AND (?6 <= 0 OR movies.rating >= ?6)
+    AND (?7 <= 0 OR movies.rating <= ?7)
+    AND (?8 IS NULL OR CASE WHEN ?9
+        THEN CASE ?10
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END < ?11
+            OR (CASE ?10
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = ?11 AND movies.id < ?8)
+        ELSE CASE ?10
+            WHEN 'added_at' THEN movies.added_at
+            WHEN 'rating' THEN movies.rating
+            WHEN 'id' THEN movies.id
+            ELSE movies.title
+        END > ?11
+            OR (CASE ?10
+                WHEN 'added_at' THEN movies.added_at
+                WHEN 'rating' THEN movies.rating
+                WHEN 'id' THEN movies.id
+                ELSE movies.title
+            END = ?11 AND movies.id > ?8)
+    END)
+ORDER BY
+    CASE WHEN ?9 THEN CASE ?10
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END END DESC,
+    CASE WHEN ?9 THEN movies.id END DESC,
+    CASE ?10
+        WHEN 'added_at' THEN movies.added_at
+        WHEN 'rating' THEN movies.rating
+        WHEN 'id' THEN movies.id
+        ELSE movies.title
+    END ASC,
+    movies.id ASC
+LIMIT CASE WHEN ?12 > 0 THEN ?12 ELSE -1 END
+OFFSET ?13
+
+
@@ -0,0 +1,50 @@
+-- name: GetMovie :one
+SELECT
+    movies.id,
+    movies.title,
+    movies.added_at,
+    movies.rating,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT people.name
+            FROM movie_directors
+            JOIN people ON people.id = movie_directors.person_id
+            WHERE movie_directors.movie_id = movies.id
+            ORDER BY people.name ASC
+        )
+    ) AS TEXT) AS directors,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT people.name
+            FROM movie_actors
+            JOIN people ON people.id = movie_actors.person_id
+            WHERE movie_actors.movie_id = movies.id
+            ORDER BY people.name ASC
+        )
+    ) AS TEXT) AS actors,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT countries.name
+            FROM movie_countries
+            JOIN countries ON countries.id = movie_countries.country_id
+            WHERE movie_countries.movie_id = movies.id
+            ORDER BY countries.name ASC
+        )
+    ) AS TEXT) AS countries,
+    CAST((
+        SELECT json_group_array(name)
+        FROM (
+            SELECT genres.name
+            FROM movie_genres
+            JOIN genres ON genres.id = movie_genres.genre_id
+            WHERE movie_genres.movie_id = movies.id
+            ORDER BY genres.name
+        )
+    ) AS TEXT) AS genres
+FROM movies
+WHERE movies.id = ?
+
+
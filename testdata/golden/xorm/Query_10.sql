@@ -0,0 +1,10 @@
+SELECT `id`, `title`, `added_at`, `rating` FROM `movies` WHERE movies.rating>=? ORDER BY movies.title ASC, movies.id ASC LIMIT 10
+
+SELECT movie_directors.movie_id, people.name FROM `movie_directors` INNER JOIN `people` ON people.id = movie_directors.person_id WHERE `movie_directors`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?) ORDER BY people.name
+
+SELECT movie_actors.movie_id, people.name FROM `movie_actors` INNER JOIN `people` ON people.id = movie_actors.person_id WHERE `movie_actors`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?) ORDER BY people.name
+
+SELECT movie_countries.movie_id, countries.name FROM `movie_countries` INNER JOIN `countries` ON countries.id = movie_countries.country_id WHERE `movie_countries`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?) ORDER BY countries.name
+
+SELECT movie_genres.movie_id, genres.name FROM `movie_genres` INNER JOIN `genres` ON genres.id = movie_genres.genre_id WHERE `movie_genres`.`movie_id` IN (?,?,?,?,?,?,?,?,?,?) ORDER BY genres.name
+
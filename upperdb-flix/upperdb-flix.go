@@ -0,0 +1,427 @@
+package upperdbflix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upper/db/v4"
+	"github.com/upper/db/v4/adapter/sqlite"
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+)
+
+func init() {
+	drivers.Register("upperdb", NewRepository)
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	sqldb, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// sqlite.New wraps the database of any driver, while sqlite.Open always
+	// uses mattn/go-sqlite3.
+	sess, err := sqlite.New(sqldb)
+	if err != nil {
+		return nil, err
+	}
+
+	// upper/db logs every failed statement, like an expected constraint
+	// violation, and every slow one as a warning to stderr. It has no logger
+	// per session, so the warnings are turned off for all sessions.
+	db.LC().SetLevel(db.LogLevelError)
+
+	_, err = sess.SQL().Exec(
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		DB: sess,
+	}, nil
+}
+
+type Repository struct {
+	DB db.Session
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	result, err := r.DB.SQL().
+		DeleteFrom("movies").
+		Where(db.Cond{"id": id}).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+// Row is a row of the movies table.
+type Row struct {
+	ID      int64     `db:"id"`
+	Title   string    `db:"title"`
+	AddedAt time.Time `db:"added_at"`
+	Rating  float64   `db:"rating"`
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) error {
+	return r.DB.TxContext(ctx, func(tx db.Session) error {
+		_, err := tx.Collection("movies").Insert(Row{
+			ID:      movie.ID,
+			Title:   movie.Title,
+			AddedAt: movie.AddedAt,
+			Rating:  movie.Rating,
+		})
+		if err != nil {
+			if drivers.IsPrimaryKeyViolation(err) {
+				return benchflix.ErrAlreadyExists
+			}
+
+			return err
+		}
+
+		return insertRelations(ctx, tx, movie)
+	}, nil)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) error {
+	return r.DB.TxContext(ctx, func(tx db.Session) error {
+		result, err := tx.SQL().
+			Update("movies").
+			Set("title", movie.Title, "added_at", movie.AddedAt, "rating", movie.Rating).
+			Where(db.Cond{"id": movie.ID}).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return benchflix.ErrNotFound
+		}
+
+		for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+			_, err = tx.SQL().
+				DeleteFrom(table).
+				Where(db.Cond{"movie_id": movie.ID}).
+				ExecContext(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return insertRelations(ctx, tx, movie)
+	}, nil)
+}
+
+func insertRelations(ctx context.Context, tx db.Session, movie benchflix.Movie) error {
+	if err := insertNames(ctx, tx, "people", "movie_directors", "person_id", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "people", "movie_actors", "person_id", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(ctx, tx, "countries", "movie_countries", "country_id", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(ctx, tx, "genres", "movie_genres", "genre_id", movie.ID, movie.Genres)
+}
+
+// Name is a row of the people, countries or genres table.
+type Name struct {
+	ID int64 `db:"id"`
+}
+
+// insertNames upserts names into table and links their IDs to the movie. The
+// builder of upper/db has no ON CONFLICT clause, so the upsert is plain SQL.
+func insertNames(ctx context.Context, tx db.Session, table, links, column string, movieID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(names))
+
+	for i, name := range names {
+		args[i] = name
+	}
+
+	var rows []Name
+
+	err := tx.SQL().IteratorContext(ctx,
+		fmt.Sprintf(
+			`INSERT INTO %s (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = excluded.name RETURNING id`,
+			table, strings.Repeat(",(?)", len(names))[1:],
+		),
+		args...,
+	).All(&rows)
+	if err != nil {
+		return err
+	}
+
+	insert := tx.SQL().InsertInto(links).Columns("movie_id", column)
+
+	for _, row := range rows {
+		insert = insert.Values(movieID, row.ID)
+	}
+
+	_, err = insert.ExecContext(ctx)
+
+	return err
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) []db.LogicalExpr {
+	var conditions []db.LogicalExpr
+
+	if query.Search != "" {
+		conditions = append(conditions, db.Or(
+			exists("movie_directors", "people", "person_id", "INSTR(people.name, ?) > 0", query.Search),
+			exists("movie_actors", "people", "person_id", "INSTR(people.name, ?) > 0", query.Search),
+		))
+	}
+
+	if query.Genre != "" {
+		conditions = append(conditions, exists("movie_genres", "genres", "genre_id", "genres.name = ?", query.Genre))
+	}
+
+	if query.Country != "" {
+		conditions = append(conditions, exists("movie_countries", "countries", "country_id", "countries.name = ?", query.Country))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		conditions = append(conditions, db.Cond{"movies.added_at <": query.AddedBefore})
+	}
+
+	if !query.AddedAfter.IsZero() {
+		conditions = append(conditions, db.Cond{"movies.added_at >": query.AddedAfter})
+	}
+
+	if query.MinRating > 0 {
+		conditions = append(conditions, db.Cond{"movies.rating >=": query.MinRating})
+	}
+
+	if query.MaxRating > 0 {
+		conditions = append(conditions, db.Cond{"movies.rating <=": query.MaxRating})
+	}
+
+	return conditions
+}
+
+// exists matches movies linked to a row of table that satisfies condition.
+func exists(links, table, column, condition string, arg any) db.LogicalExpr {
+	return db.Raw(fmt.Sprintf(
+		`EXISTS (
+			SELECT 1 FROM %[1]s
+			JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s
+			WHERE %[1]s.movie_id = movies.id AND %[4]s
+		)`,
+		links, table, column, condition,
+	), arg)
+}
+
+// aggregate selects the names linked to a movie as a JSON array.
+func aggregate(links, table, column, alias string) *db.RawExpr {
+	return db.Raw(fmt.Sprintf(
+		`(
+			SELECT json_group_array(%[2]s.name ORDER BY %[2]s.name)
+			FROM %[1]s
+			JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s
+			WHERE %[1]s.movie_id = movies.id
+		) AS %[4]s`,
+		links, table, column, alias,
+	))
+}
+
+var columns = []any{
+	"movies.id",
+	"movies.title",
+	"movies.added_at",
+	"movies.rating",
+	aggregate("movie_directors", "people", "person_id", "directors"),
+	aggregate("movie_actors", "people", "person_id", "actors"),
+	aggregate("movie_countries", "countries", "country_id", "countries"),
+	aggregate("movie_genres", "genres", "genre_id", "genres"),
+}
+
+type Movie struct {
+	Row       `db:",inline"`
+	Directors []byte `db:"directors"`
+	Actors    []byte `db:"actors"`
+	Countries []byte `db:"countries"`
+	Genres    []byte `db:"genres"`
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := filter(query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		conditions = append(conditions, db.Raw(
+			fmt.Sprintf("(movies.%s, movies.id) %s (?, ?)", column, operator),
+			query.After.Value(query.Sort.Field), query.After.ID,
+		))
+	}
+
+	stmt := r.DB.SQL().
+		Select(columns...).
+		From("movies").
+		Where(db.And(conditions...))
+
+	if query.Sort.Descending {
+		stmt = stmt.OrderBy("-movies."+column, "-movies.id")
+	} else {
+		stmt = stmt.OrderBy("movies."+column, "movies.id")
+	}
+
+	if query.Limit > 0 {
+		stmt = stmt.Limit(int(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		// Without a limit, upper/db writes LIMIT -1, because SQLite does not
+		// accept OFFSET alone.
+		stmt = stmt.Offset(int(query.Offset))
+	}
+
+	var movies []Movie
+
+	if err = stmt.IteratorContext(ctx).All(&movies); err != nil {
+		return nil, err
+	}
+
+	result := make([]benchflix.Movie, len(movies))
+
+	for i, movie := range movies {
+		result[i], err = ConvertMovie(movie)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	err := r.DB.SQL().
+		Select(columns...).
+		From("movies").
+		Where(db.Cond{"movies.id": id}).
+		IteratorContext(ctx).
+		One(&movie)
+	if err != nil {
+		if errors.Is(err, db.ErrNoMoreRows) {
+			return benchflix.Movie{}, benchflix.ErrNotFound
+		}
+
+		return benchflix.Movie{}, err
+	}
+
+	return ConvertMovie(movie)
+}
+
+// ConvertMovie decodes the names aggregated with json_group_array.
+func ConvertMovie(movie Movie) (benchflix.Movie, error) {
+	result := benchflix.Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	}
+
+	if err := json.Unmarshal(movie.Directors, &result.Directors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Actors, &result.Actors); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Countries, &result.Countries); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if err := json.Unmarshal(movie.Genres, &result.Genres); err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,526 @@
+package xormflix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+	"xorm.io/xorm/dialects"
+	"xorm.io/xorm/names"
+)
+
+func init() {
+	drivers.Register("xorm", NewRepository)
+}
+
+var dialectsMu sync.Mutex
+
+// registerDialect registers the SQLite dialect of xorm for driverName, because
+// xorm looks up the dialect by the driver name.
+func registerDialect(driverName string) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	if dialects.QueryDriver(driverName) == nil {
+		dialects.RegisterDriver(driverName, dialects.QueryDriver("sqlite3"))
+	}
+}
+
+type Movie struct {
+	ID      int64     `xorm:"pk"`
+	Title   string    `xorm:"notnull"`
+	AddedAt time.Time `xorm:"notnull"`
+	Rating  float64   `xorm:"notnull"`
+}
+
+func (Movie) TableName() string {
+	return "movies"
+}
+
+type Person struct {
+	ID   int64  `xorm:"pk autoincr"`
+	Name string `xorm:"notnull unique"`
+}
+
+func (Person) TableName() string {
+	return "people"
+}
+
+type MovieDirector struct {
+	MovieID  int64 `xorm:"pk"`
+	PersonID int64 `xorm:"pk"`
+}
+
+func (MovieDirector) TableName() string {
+	return "movie_directors"
+}
+
+type MovieActor struct {
+	MovieID  int64 `xorm:"pk"`
+	PersonID int64 `xorm:"pk"`
+}
+
+func (MovieActor) TableName() string {
+	return "movie_actors"
+}
+
+type MovieCountry struct {
+	MovieID   int64 `xorm:"pk"`
+	CountryID int64 `xorm:"pk"`
+}
+
+func (MovieCountry) TableName() string {
+	return "movie_countries"
+}
+
+type MovieGenre struct {
+	MovieID int64 `xorm:"pk"`
+	GenreID int64 `xorm:"pk"`
+}
+
+func (MovieGenre) TableName() string {
+	return "movie_genres"
+}
+
+// NameRow is a name linked to a movie, used to load the relations of movies.
+type NameRow struct {
+	MovieID int64
+	Name    string
+}
+
+func NewRepository(driverName, dataSourceName string) (benchflix.Repository, error) {
+	registerDialect(driverName)
+
+	engine, err := xorm.NewEngine(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// GonicMapper maps MovieID to movie_id instead of movie_i_d.
+	engine.SetMapper(names.GonicMapper{})
+
+	// Read times as they were written, independent of the local time zone.
+	engine.SetTZLocation(time.UTC)
+	engine.SetTZDatabase(time.UTC)
+
+	// xorm does not create foreign keys, so the schema is written by hand to
+	// cascade deletes to the relations.
+	_, err = engine.Exec(
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATETIME NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		Engine: engine,
+	}, nil
+}
+
+type Repository struct {
+	Engine *xorm.Engine
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	affected, err := r.Engine.Context(ctx).ID(id).Delete(&Movie{})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	session := r.Engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err = session.Begin(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Rollback())
+		} else {
+			err = session.Commit()
+		}
+	}()
+
+	_, err = session.Insert(&Movie{
+		ID:      movie.ID,
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	})
+	if err != nil {
+		if drivers.IsPrimaryKeyViolation(err) {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(session, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	session := r.Engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err = session.Begin(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Rollback())
+		} else {
+			err = session.Commit()
+		}
+	}()
+
+	affected, err := session.ID(movie.ID).Cols("title", "added_at", "rating").Update(&Movie{
+		Title:   movie.Title,
+		AddedAt: movie.AddedAt,
+		Rating:  movie.Rating,
+	})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, bean := range []any{&MovieDirector{}, &MovieActor{}, &MovieCountry{}, &MovieGenre{}} {
+		if _, err = session.Where("movie_id = ?", movie.ID).Delete(bean); err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(session, movie)
+}
+
+func insertRelations(session *xorm.Session, movie benchflix.Movie) error {
+	directorIDs, err := insertNames(session, "people", movie.Directors)
+	if err != nil {
+		return err
+	}
+
+	directors := make([]MovieDirector, len(directorIDs))
+
+	for i, id := range directorIDs {
+		directors[i] = MovieDirector{MovieID: movie.ID, PersonID: id}
+	}
+
+	actorIDs, err := insertNames(session, "people", movie.Actors)
+	if err != nil {
+		return err
+	}
+
+	actors := make([]MovieActor, len(actorIDs))
+
+	for i, id := range actorIDs {
+		actors[i] = MovieActor{MovieID: movie.ID, PersonID: id}
+	}
+
+	countryIDs, err := insertNames(session, "countries", movie.Countries)
+	if err != nil {
+		return err
+	}
+
+	countries := make([]MovieCountry, len(countryIDs))
+
+	for i, id := range countryIDs {
+		countries[i] = MovieCountry{MovieID: movie.ID, CountryID: id}
+	}
+
+	genreIDs, err := insertNames(session, "genres", movie.Genres)
+	if err != nil {
+		return err
+	}
+
+	genres := make([]MovieGenre, len(genreIDs))
+
+	for i, id := range genreIDs {
+		genres[i] = MovieGenre{MovieID: movie.ID, GenreID: id}
+	}
+
+	// xorm rejects empty slices, so they are skipped.
+	for _, links := range []any{directors, actors, countries, genres} {
+		if reflect.ValueOf(links).Len() == 0 {
+			continue
+		}
+
+		if _, err = session.Insert(links); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertNames upserts names into table and returns their IDs. xorm has no
+// upsert, so the statement is written by hand.
+func insertNames(session *xorm.Session, table string, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, len(names))
+
+	for i, name := range names {
+		args[i] = name
+	}
+
+	var ids []int64
+
+	err := session.SQL(
+		fmt.Sprintf(
+			`INSERT INTO %s (name) VALUES %s ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id`,
+			table, strings.Repeat(",(?)", len(names))[1:],
+		),
+		args...,
+	).Find(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// filter returns the conditions of query on the movies table.
+func filter(query benchflix.Query) builder.Cond {
+	cond := builder.NewCond()
+
+	if query.Search != "" {
+		cond = cond.And(builder.Or(
+			builder.Expr(`EXISTS (
+				SELECT 1 FROM movie_directors
+				JOIN people ON people.id = movie_directors.person_id
+				WHERE movie_directors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)`, query.Search),
+			builder.Expr(`EXISTS (
+				SELECT 1 FROM movie_actors
+				JOIN people ON people.id = movie_actors.person_id
+				WHERE movie_actors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)`, query.Search),
+		))
+	}
+
+	if query.Genre != "" {
+		cond = cond.And(builder.Expr(`EXISTS (
+			SELECT 1 FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id AND genres.name = ?
+		)`, query.Genre))
+	}
+
+	if query.Country != "" {
+		cond = cond.And(builder.Expr(`EXISTS (
+			SELECT 1 FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id AND countries.name = ?
+		)`, query.Country))
+	}
+
+	if !query.AddedBefore.IsZero() {
+		cond = cond.And(builder.Lt{"movies.added_at": formatTime(query.AddedBefore)})
+	}
+
+	if !query.AddedAfter.IsZero() {
+		cond = cond.And(builder.Gt{"movies.added_at": formatTime(query.AddedAfter)})
+	}
+
+	if query.MinRating > 0 {
+		cond = cond.And(builder.Gte{"movies.rating": query.MinRating})
+	}
+
+	if query.MaxRating > 0 {
+		cond = cond.And(builder.Lte{"movies.rating": query.MaxRating})
+	}
+
+	return cond
+}
+
+// formatTime formats t like xorm writes DATETIME columns, so that the text of
+// the column compares correctly.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) ([]benchflix.Movie, error) {
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	cond := filter(query)
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		value := query.After.Value(query.Sort.Field)
+		if t, ok := value.(time.Time); ok {
+			value = formatTime(t)
+		}
+
+		cond = cond.And(builder.Expr(
+			fmt.Sprintf(`(movies.%s, movies.id) %s (?, ?)`, column, operator),
+			value, query.After.ID,
+		))
+	}
+
+	session := r.Engine.Context(ctx).Where(cond).
+		OrderBy(fmt.Sprintf("movies.%s %s, movies.id %s", column, query.Sort.Direction(), query.Sort.Direction()))
+
+	if query.Limit > 0 || query.Offset > 0 {
+		limit := -1
+		if query.Limit > 0 {
+			limit = int(query.Limit)
+		}
+
+		session = session.Limit(limit, int(query.Offset))
+	}
+
+	var movies []Movie
+
+	if err = session.Find(&movies); err != nil {
+		return nil, err
+	}
+
+	return r.load(ctx, movies)
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	var movie Movie
+
+	has, err := r.Engine.Context(ctx).ID(id).Get(&movie)
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if !has {
+		return benchflix.Movie{}, benchflix.ErrNotFound
+	}
+
+	movies, err := r.load(ctx, []Movie{movie})
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	return movies[0], nil
+}
+
+// load queries the names of the relations of movies, one statement per
+// relation, like a preload.
+func (r Repository) load(ctx context.Context, movies []Movie) ([]benchflix.Movie, error) {
+	result := make([]benchflix.Movie, len(movies))
+	index := make(map[int64]*benchflix.Movie, len(movies))
+	ids := make([]int64, len(movies))
+
+	for i, movie := range movies {
+		result[i] = benchflix.Movie{
+			ID:      movie.ID,
+			Title:   movie.Title,
+			AddedAt: movie.AddedAt,
+			Rating:  movie.Rating,
+		}
+
+		index[movie.ID] = &result[i]
+		ids[i] = movie.ID
+	}
+
+	if len(movies) == 0 {
+		return nil, nil
+	}
+
+	for _, relation := range []struct {
+		links, table, column string
+		names                func(*benchflix.Movie) *[]string
+	}{
+		{"movie_directors", "people", "person_id", func(m *benchflix.Movie) *[]string { return &m.Directors }},
+		{"movie_actors", "people", "person_id", func(m *benchflix.Movie) *[]string { return &m.Actors }},
+		{"movie_countries", "countries", "country_id", func(m *benchflix.Movie) *[]string { return &m.Countries }},
+		{"movie_genres", "genres", "genre_id", func(m *benchflix.Movie) *[]string { return &m.Genres }},
+	} {
+		var rows []NameRow
+
+		err := r.Engine.Context(ctx).
+			Table(relation.links).
+			Select(relation.links+".movie_id, "+relation.table+".name").
+			Join("INNER", relation.table, fmt.Sprintf("%s.id = %s.%s", relation.table, relation.links, relation.column)).
+			In(relation.links+".movie_id", ids).
+			OrderBy(relation.table + ".name").
+			Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			names := relation.names(index[row.MovieID])
+
+			*names = append(*names, row.Name)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,574 @@
+// Package zombiezenflix talks to SQLite through zombiezen.com/go/sqlite instead
+// of database/sql. Statements are prepared once per connection and reused,
+// arguments are bound and columns are read by hand, so the difference to
+// sql-flix is the cost of database/sql itself. The statements and rows are
+// counted and traced with drivers.Executed and drivers.RowsRead.
+package zombiezenflix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	benchflix "github.com/wroge/bench-flix"
+	"github.com/wroge/bench-flix/drivers"
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+func init() {
+	benchflix.Register("zombiezen", NewRepository)
+}
+
+// timeFormat is the format mattn/go-sqlite3 writes times in, so that DATE
+// columns compare the same as in the database/sql implementations.
+const timeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+func NewRepository(dsn string) (benchflix.Repository, error) {
+	name, pragmas, _ := drivers.Pragmas(dsn)
+
+	options := sqlitex.PoolOptions{
+		PrepareConn: func(conn *sqlite.Conn) error {
+			for _, pragma := range pragmas {
+				if err := sqlitex.ExecuteTransient(conn, "PRAGMA "+pragma+";", nil); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	if strings.HasPrefix(name, ":memory:") || strings.HasPrefix(name, "file::memory:") {
+		// Every connection opens its own in-memory database.
+		name, options.PoolSize = "file::memory:", 1
+	}
+
+	pool, err := sqlitex.NewPool(name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.Take(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	defer pool.Put(conn)
+
+	err = sqlitex.ExecuteScript(conn,
+		`CREATE TABLE movies (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			added_at DATE NOT NULL,
+			rating NUMERIC NOT NULL
+		);
+
+		CREATE TABLE people (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_directors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE movie_actors (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			person_id INTEGER REFERENCES people (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, person_id)
+		);
+
+		CREATE TABLE countries (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_countries (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			country_id INTEGER REFERENCES countries (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, country_id)
+		);
+
+		CREATE TABLE genres (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE
+		);
+
+		CREATE TABLE movie_genres (
+			movie_id INTEGER REFERENCES movies (id) ON DELETE CASCADE,
+			genre_id INTEGER REFERENCES genres (id) ON DELETE CASCADE,
+			PRIMARY KEY (movie_id, genre_id)
+		);`, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Repository{
+		Pool: pool,
+	}, nil
+}
+
+type Repository struct {
+	Pool *sqlitex.Pool
+}
+
+func (r Repository) Delete(ctx context.Context, id int64) error {
+	conn, err := r.Pool.Take(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer r.Pool.Put(conn)
+
+	stmt, err := prepare(conn, "DELETE FROM movies WHERE id = ?;", id)
+	if err != nil {
+		return err
+	}
+
+	if err = exec(stmt); err != nil {
+		return err
+	}
+
+	if conn.Changes() == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, movie benchflix.Movie) (err error) {
+	conn, err := r.Pool.Take(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer r.Pool.Put(conn)
+
+	end, err := sqlitex.ImmediateTransaction(conn)
+	if err != nil {
+		return err
+	}
+
+	defer end(&err)
+
+	stmt, err := prepare(conn, "INSERT INTO movies (id, title, added_at, rating) VALUES (?, ?, ?, ?);",
+		movie.ID, movie.Title, movie.AddedAt, movie.Rating)
+	if err != nil {
+		return err
+	}
+
+	if err = exec(stmt); err != nil {
+		if sqlite.ErrCode(err) == sqlite.ResultConstraintPrimaryKey {
+			return benchflix.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return insertRelations(conn, movie)
+}
+
+func (r Repository) Update(ctx context.Context, movie benchflix.Movie) (err error) {
+	conn, err := r.Pool.Take(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer r.Pool.Put(conn)
+
+	end, err := sqlitex.ImmediateTransaction(conn)
+	if err != nil {
+		return err
+	}
+
+	defer end(&err)
+
+	stmt, err := prepare(conn, "UPDATE movies SET title = ?, added_at = ?, rating = ? WHERE id = ?;",
+		movie.Title, movie.AddedAt, movie.Rating, movie.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = exec(stmt); err != nil {
+		return err
+	}
+
+	if conn.Changes() == 0 {
+		return benchflix.ErrNotFound
+	}
+
+	for _, table := range []string{"movie_directors", "movie_actors", "movie_countries", "movie_genres"} {
+		stmt, err = prepare(conn, "DELETE FROM "+table+" WHERE movie_id = ?;", movie.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return insertRelations(conn, movie)
+}
+
+func insertRelations(conn *sqlite.Conn, movie benchflix.Movie) error {
+	if err := insertNames(conn, "people", "movie_directors (movie_id, person_id)", movie.ID, movie.Directors); err != nil {
+		return err
+	}
+
+	if err := insertNames(conn, "people", "movie_actors (movie_id, person_id)", movie.ID, movie.Actors); err != nil {
+		return err
+	}
+
+	if err := insertNames(conn, "countries", "movie_countries (movie_id, country_id)", movie.ID, movie.Countries); err != nil {
+		return err
+	}
+
+	return insertNames(conn, "genres", "movie_genres (movie_id, genre_id)", movie.ID, movie.Genres)
+}
+
+// insertNames upserts names into table one by one and links their IDs to the
+// movie, so that the same two statements are reused for every name.
+func insertNames(conn *sqlite.Conn, table, links string, movieID int64, names []string) error {
+	for _, name := range names {
+		stmt, err := prepare(conn, "INSERT INTO "+table+" (name) VALUES (?) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id;", name)
+		if err != nil {
+			return err
+		}
+
+		id, err := sqlitex.ResultInt64(stmt)
+		if err != nil {
+			return err
+		}
+
+		drivers.RowsRead(1)
+
+		stmt, err = prepare(conn, "INSERT INTO "+links+" VALUES (?, ?);", movieID, id)
+		if err != nil {
+			return err
+		}
+
+		if err = exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prepare returns the cached statement of query on conn with args bound, and
+// reports it to drivers.Executed, like the counting drivers do for database/sql.
+func prepare(conn *sqlite.Conn, query string, args ...any) (*sqlite.Stmt, error) {
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bind(stmt, args); err != nil {
+		return nil, err
+	}
+
+	drivers.Executed(query, args, func() ([]drivers.PlanRow, error) {
+		return explain(conn, query, args)
+	})
+
+	return stmt, nil
+}
+
+// explain runs EXPLAIN QUERY PLAN for query on conn for drivers.Explain.
+func explain(conn *sqlite.Conn, query string, args []any) (_ []drivers.PlanRow, err error) {
+	stmt, _, err := conn.PrepareTransient("EXPLAIN QUERY PLAN " + query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = errors.Join(err, stmt.Finalize())
+	}()
+
+	if err = bind(stmt, args); err != nil {
+		return nil, err
+	}
+
+	var plan []drivers.PlanRow
+
+	for {
+		row, err := stmt.Step()
+		if err != nil {
+			return nil, err
+		}
+
+		if !row {
+			return plan, nil
+		}
+
+		plan = append(plan, drivers.PlanRow{
+			ID:     stmt.ColumnInt64(0),
+			Parent: stmt.ColumnInt64(1),
+			Detail: stmt.ColumnText(3),
+		})
+	}
+}
+
+// exec runs stmt, which returns no rows, and resets it for the next use.
+func exec(stmt *sqlite.Stmt) error {
+	defer stmt.Reset()
+
+	_, err := stmt.Step()
+
+	return err
+}
+
+// bind binds args to the parameters of stmt in order.
+func bind(stmt *sqlite.Stmt, args []any) error {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			stmt.BindText(i+1, v)
+		case int64:
+			stmt.BindInt64(i+1, v)
+		case uint64:
+			stmt.BindInt64(i+1, int64(v))
+		case float64:
+			stmt.BindFloat(i+1, v)
+		case time.Time:
+			stmt.BindText(i+1, v.Format(timeFormat))
+		default:
+			return fmt.Errorf("zombiezenflix: unsupported argument %T", arg)
+		}
+	}
+
+	return nil
+}
+
+// filter writes the conditions of query to a WHERE clause of the movies table.
+func filter(query benchflix.Query) (*strings.Builder, []any) {
+	builder := &strings.Builder{}
+	args := []any{}
+
+	if query.Search != "" {
+		builder.WriteString(`AND (
+			EXISTS (
+				SELECT 1 FROM movie_directors
+				JOIN people ON people.id = movie_directors.person_id
+				WHERE movie_directors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)
+			OR EXISTS (
+				SELECT 1 FROM movie_actors
+				JOIN people ON people.id = movie_actors.person_id
+				WHERE movie_actors.movie_id = movies.id AND INSTR(people.name, ?) > 0
+			)
+		)`)
+
+		args = append(args, query.Search, query.Search)
+	}
+
+	if query.Genre != "" {
+		builder.WriteString(`AND EXISTS (
+			SELECT 1 FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id AND genres.name = ?
+		)`)
+
+		args = append(args, query.Genre)
+	}
+
+	if query.Country != "" {
+		builder.WriteString(`AND EXISTS (
+			SELECT 1 FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id AND countries.name = ?
+		)`)
+
+		args = append(args, query.Country)
+	}
+
+	if !query.AddedBefore.IsZero() {
+		builder.WriteString(` AND added_at < ?`)
+
+		args = append(args, query.AddedBefore)
+	}
+
+	if !query.AddedAfter.IsZero() {
+		builder.WriteString(` AND added_at > ?`)
+
+		args = append(args, query.AddedAfter)
+	}
+
+	if query.MinRating > 0 {
+		builder.WriteString(` AND rating >= ?`)
+
+		args = append(args, query.MinRating)
+	}
+
+	if query.MaxRating > 0 {
+		builder.WriteString(` AND rating <= ?`)
+
+		args = append(args, query.MaxRating)
+	}
+
+	return builder, args
+}
+
+const selectMovies = `SELECT
+		movies.id,
+		movies.title,
+		movies.added_at,
+		movies.rating,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_directors
+			JOIN people ON people.id = movie_directors.person_id
+			WHERE movie_directors.movie_id = movies.id
+		) AS directors,
+		(
+			SELECT json_group_array(people.name ORDER BY people.name)
+			FROM movie_actors
+			JOIN people ON people.id = movie_actors.person_id
+			WHERE movie_actors.movie_id = movies.id
+		) AS actors,
+		(
+			SELECT json_group_array(countries.name ORDER BY countries.name)
+			FROM movie_countries
+			JOIN countries ON countries.id = movie_countries.country_id
+			WHERE movie_countries.movie_id = movies.id
+		) AS countries,
+		(
+			SELECT json_group_array(genres.name ORDER BY genres.name)
+			FROM movie_genres
+			JOIN genres ON genres.id = movie_genres.genre_id
+			WHERE movie_genres.movie_id = movies.id
+		) AS genres
+	FROM movies`
+
+func (r Repository) Query(ctx context.Context, query benchflix.Query) (movies []benchflix.Movie, err error) {
+	builder, args := filter(query)
+
+	column, err := query.Sort.Column()
+	if err != nil {
+		return nil, err
+	}
+
+	if query.After != nil {
+		operator := ">"
+		if query.Sort.Descending {
+			operator = "<"
+		}
+
+		fmt.Fprintf(builder, ` AND (movies.%s, movies.id) %s (?, ?)`, column, operator)
+
+		args = append(args, query.After.Value(query.Sort.Field), query.After.ID)
+	}
+
+	fmt.Fprintf(builder, " ORDER BY movies.%s %s, movies.id %s", column, query.Sort.Direction(), query.Sort.Direction())
+
+	if query.Limit > 0 {
+		builder.WriteString(" LIMIT ?")
+
+		args = append(args, query.Limit)
+	}
+
+	if query.Offset > 0 {
+		if query.Limit == 0 {
+			builder.WriteString(" LIMIT -1")
+		}
+
+		builder.WriteString(" OFFSET ?")
+
+		args = append(args, query.Offset)
+	}
+
+	conn, err := r.Pool.Take(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Pool.Put(conn)
+
+	// The statement only depends on the shape of query, so the cache of the
+	// connection holds one prepared statement per combination of filters.
+	stmt, err := prepare(conn, selectMovies+" WHERE 1=1 "+builder.String()+";", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Reset()
+
+	for {
+		row, err := stmt.Step()
+		if err != nil {
+			return nil, err
+		}
+
+		if !row {
+			return movies, nil
+		}
+
+		drivers.RowsRead(1)
+
+		movie, err := readMovie(stmt)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, movie)
+	}
+}
+
+func (r Repository) Read(ctx context.Context, id int64) (benchflix.Movie, error) {
+	conn, err := r.Pool.Take(ctx)
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	defer r.Pool.Put(conn)
+
+	stmt, err := prepare(conn, selectMovies+" WHERE movies.id = ?;", id)
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	defer stmt.Reset()
+
+	row, err := stmt.Step()
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	if !row {
+		return benchflix.Movie{}, benchflix.ErrNotFound
+	}
+
+	drivers.RowsRead(1)
+
+	return readMovie(stmt)
+}
+
+// readMovie reads the columns of selectMovies from the current row of stmt.
+func readMovie(stmt *sqlite.Stmt) (benchflix.Movie, error) {
+	addedAt, err := time.ParseInLocation(timeFormat, stmt.ColumnText(2), time.UTC)
+	if err != nil {
+		return benchflix.Movie{}, err
+	}
+
+	movie := benchflix.Movie{
+		ID:      stmt.ColumnInt64(0),
+		Title:   stmt.ColumnText(1),
+		AddedAt: addedAt,
+		Rating:  stmt.ColumnFloat(3),
+	}
+
+	for i, names := range []*[]string{&movie.Directors, &movie.Actors, &movie.Countries, &movie.Genres} {
+		if err = json.Unmarshal([]byte(stmt.ColumnText(4+i)), names); err != nil {
+			return benchflix.Movie{}, err
+		}
+	}
+
+	return movie, nil
+}